@@ -0,0 +1,210 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package auth wires the admin API to an OIDC provider: discovery, JWKS-backed
+// ID token verification, and short-lived signed session cookies.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// Config holds the settings needed to talk to an OIDC provider and to sign
+// the session cookies issued after a successful login.
+type Config struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	AdminGroup    string
+	SessionSecret string
+	SessionTTL    time.Duration
+}
+
+// Claims is the subset of an ID token's claims the admin API cares about.
+type Claims struct {
+	Subject string   `json:"sub"`
+	Email   string   `json:"email"`
+	Name    string   `json:"name"`
+	Groups  []string `json:"groups"`
+}
+
+// HasGroup reports whether group is among the claims' group memberships.
+func (c *Claims) HasGroup(group string) bool {
+	for _, g := range c.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Provider performs the OIDC authorization-code flow against a discovered
+// issuer and issues/validates the session cookies built from it.
+type Provider struct {
+	cfg          Config
+	oidcProvider *oidc.Provider
+	verifier     *oidc.IDTokenVerifier
+	oauth2Config oauth2.Config
+}
+
+// NewProvider discovers cfg.IssuerURL's OIDC configuration (authorization,
+// token and JWKS endpoints) and returns a Provider ready to drive the login
+// flow.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	if cfg.SessionTTL == 0 {
+		cfg.SessionTTL = time.Hour
+	}
+
+	oidcProvider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+
+	return &Provider{
+		cfg:          cfg,
+		oidcProvider: oidcProvider,
+		verifier:     oidcProvider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		oauth2Config: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     oidcProvider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "profile", "email", "groups"},
+		},
+	}, nil
+}
+
+// AuthCodeURL returns the URL to redirect the user to in order to start the
+// authorization-code flow, binding state (CSRF protection) and nonce
+// (replay protection) to this login attempt.
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	return p.oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce))
+}
+
+// Exchange trades an authorization code for tokens, verifies the returned ID
+// token's signature against the provider's JWKS, and checks iss/aud/exp/nonce.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*Claims, error) {
+	token, err := p.oauth2Config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response did not include an id_token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	var claims Claims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("parse id token claims: %w", err)
+	}
+	claims.Subject = idToken.Subject
+
+	return &claims, nil
+}
+
+type session struct {
+	Claims    Claims    `json:"claims"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewSessionCookie packages claims into a signed, base64-encoded value
+// suitable for a cookie, valid for p.cfg.SessionTTL.
+func (p *Provider) NewSessionCookie(claims *Claims) (string, error) {
+	payload, err := json.Marshal(session{
+		Claims:    *claims,
+		ExpiresAt: time.Now().Add(p.cfg.SessionTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal session: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + p.sign(encoded), nil
+}
+
+// ParseSessionCookie validates the signature and expiry of a cookie value
+// produced by NewSessionCookie and returns the claims it carries.
+func (p *Provider) ParseSessionCookie(cookie string) (*Claims, error) {
+	encoded, sig, found := strings.Cut(cookie, ".")
+	if !found {
+		return nil, errors.New("malformed session cookie")
+	}
+	if !hmac.Equal([]byte(sig), []byte(p.sign(encoded))) {
+		return nil, errors.New("session cookie signature mismatch")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode session: %w", err)
+	}
+
+	var sess session
+	if err := json.Unmarshal(payload, &sess); err != nil {
+		return nil, fmt.Errorf("unmarshal session: %w", err)
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		return nil, errors.New("session expired")
+	}
+
+	return &sess.Claims, nil
+}
+
+func (p *Provider) sign(data string) string {
+	mac := hmac.New(sha256.New, []byte(p.cfg.SessionSecret))
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// AdminGroup returns the group name members must hold to reach admin-only
+// routes, as configured on the Provider.
+func (p *Provider) AdminGroup() string {
+	return p.cfg.AdminGroup
+}
+
+// RandomString returns a URL-safe, cryptographically random string of n
+// bytes of entropy, base64-encoded. It's used for OIDC state/nonce values.
+func RandomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClaimsHasGroup(t *testing.T) {
+	c := &Claims{Groups: []string{"everyone", "admins"}}
+	if !c.HasGroup("admins") {
+		t.Error("expected HasGroup(\"admins\") to be true")
+	}
+	if c.HasGroup("owners") {
+		t.Error("expected HasGroup(\"owners\") to be false")
+	}
+}
+
+func TestSessionCookieRoundTrip(t *testing.T) {
+	p := &Provider{cfg: Config{SessionSecret: "test-secret", SessionTTL: time.Hour}}
+
+	want := &Claims{Subject: "user-1", Email: "user@example.com", Groups: []string{"admins"}}
+	cookie, err := p.NewSessionCookie(want)
+	if err != nil {
+		t.Fatalf("NewSessionCookie: %s", err)
+	}
+
+	got, err := p.ParseSessionCookie(cookie)
+	if err != nil {
+		t.Fatalf("ParseSessionCookie: %s", err)
+	}
+	if got.Subject != want.Subject || got.Email != want.Email {
+		t.Errorf("ParseSessionCookie() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSessionCookieRejectsTamperedSignature(t *testing.T) {
+	p := &Provider{cfg: Config{SessionSecret: "test-secret", SessionTTL: time.Hour}}
+
+	cookie, err := p.NewSessionCookie(&Claims{Subject: "user-1"})
+	if err != nil {
+		t.Fatalf("NewSessionCookie: %s", err)
+	}
+
+	encoded, _, _ := strings.Cut(cookie, ".")
+	tampered := encoded + ".not-the-real-signature"
+
+	if _, err := p.ParseSessionCookie(tampered); err == nil {
+		t.Error("expected ParseSessionCookie to reject a tampered signature")
+	}
+}
+
+func TestSessionCookieRejectsWrongSecret(t *testing.T) {
+	issuer := &Provider{cfg: Config{SessionSecret: "secret-a", SessionTTL: time.Hour}}
+	verifier := &Provider{cfg: Config{SessionSecret: "secret-b", SessionTTL: time.Hour}}
+
+	cookie, err := issuer.NewSessionCookie(&Claims{Subject: "user-1"})
+	if err != nil {
+		t.Fatalf("NewSessionCookie: %s", err)
+	}
+
+	if _, err := verifier.ParseSessionCookie(cookie); err == nil {
+		t.Error("expected ParseSessionCookie to reject a cookie signed with a different secret")
+	}
+}
+
+func TestSessionCookieRejectsExpired(t *testing.T) {
+	p := &Provider{cfg: Config{SessionSecret: "test-secret", SessionTTL: -time.Minute}}
+
+	cookie, err := p.NewSessionCookie(&Claims{Subject: "user-1"})
+	if err != nil {
+		t.Fatalf("NewSessionCookie: %s", err)
+	}
+
+	if _, err := p.ParseSessionCookie(cookie); err == nil {
+		t.Error("expected ParseSessionCookie to reject an expired session")
+	}
+}
+
+func TestRandomStringIsUnique(t *testing.T) {
+	a, err := RandomString(16)
+	if err != nil {
+		t.Fatalf("RandomString: %s", err)
+	}
+	b, err := RandomString(16)
+	if err != nil {
+		t.Fatalf("RandomString: %s", err)
+	}
+	if a == b {
+		t.Error("expected two RandomString calls to differ")
+	}
+}
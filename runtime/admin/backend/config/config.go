@@ -0,0 +1,220 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package config loads the admin API's configuration from a YAML/TOML file
+// and/or environment variables (env takes precedence), validates it fails
+// fast with a clear message when something required is missing, and
+// watches the config file so a defined subset of non-critical fields can
+// be hot-reloaded without restarting the server.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Config is the admin API's full configuration.
+type Config struct {
+	GithubAuthMode          string `mapstructure:"github_auth_mode"`
+	GithubToken             string `mapstructure:"github_token"`
+	GithubAppID             int64  `mapstructure:"github_app_id"`
+	GithubAppInstallationID int64  `mapstructure:"github_app_installation_id"`
+	GithubAppPrivateKeyPath string `mapstructure:"github_app_private_key_path"`
+	GithubAppPrivateKey     string `mapstructure:"github_app_private_key"`
+	OrgName                 string `mapstructure:"github_org"`
+
+	SmtpHost        string `mapstructure:"smtp_host"`
+	SmtpPort        string `mapstructure:"smtp_port"`
+	SmtpUser        string `mapstructure:"smtp_user"`
+	SmtpPass        string `mapstructure:"smtp_pass"`
+	FromEmail       string `mapstructure:"from_email"`
+	SmtpStartTLS    bool   `mapstructure:"smtp_starttls"`
+	SmtpImplicitTLS bool   `mapstructure:"smtp_implicit_tls"`
+
+	MailConcurrency         int `mapstructure:"mail_concurrency"`
+	MailMaxAttempts         int `mapstructure:"mail_max_attempts"`
+	MailRetryBackoffSeconds int `mapstructure:"mail_retry_backoff_seconds"`
+
+	OidcIssuerURL    string `mapstructure:"oidc_issuer_url"`
+	OidcClientID     string `mapstructure:"oidc_client_id"`
+	OidcClientSecret string `mapstructure:"oidc_client_secret"`
+	OidcRedirectURL  string `mapstructure:"oidc_redirect_url"`
+	OidcAdminGroup   string `mapstructure:"oidc_admin_group"`
+
+	SessionSecret       string `mapstructure:"session_secret"`
+	SessionTTLSeconds   int    `mapstructure:"session_ttl_seconds"`
+	SessionCookieSecure bool   `mapstructure:"session_cookie_secure"`
+
+	DBDriver               string `mapstructure:"db_driver"`
+	DBDSN                  string `mapstructure:"db_dsn"`
+	JobPollIntervalSeconds int    `mapstructure:"job_poll_interval_seconds"`
+
+	// CORSAllowOrigins is the explicit set of origins allowed to make
+	// credentialed requests. There is deliberately no wildcard option:
+	// browsers reject "*" combined with credentialed requests anyway, and
+	// a configured allow-list is the only safe way to support cookies
+	// across origins.
+	CORSAllowOrigins []string `mapstructure:"cors_allow_origins"`
+}
+
+// MailRetryBackoff is MailRetryBackoffSeconds as a time.Duration.
+func (c Config) MailRetryBackoff() time.Duration {
+	return time.Duration(c.MailRetryBackoffSeconds) * time.Second
+}
+
+// SessionTTL is SessionTTLSeconds as a time.Duration.
+func (c Config) SessionTTL() time.Duration {
+	return time.Duration(c.SessionTTLSeconds) * time.Second
+}
+
+// JobPollInterval is JobPollIntervalSeconds as a time.Duration.
+func (c Config) JobPollInterval() time.Duration {
+	return time.Duration(c.JobPollIntervalSeconds) * time.Second
+}
+
+// applyHotReloadableFields copies the hot-reloadable keys from a reloaded
+// file into the live Config when the file changes, per the fields the admin API
+// promises to apply without a restart: SMTP credentials, the org name, the
+// CORS allow-list and mail worker concurrency. Everything else (OIDC
+// settings, session secret, database DSN, GitHub auth mode, ...) only takes
+// effect on the next process start, since picking it up live would mean
+// re-establishing connections or invalidating sessions signed with the old
+// secret.
+func (m *Manager) applyHotReloadableFields(next Config) {
+	m.cfg.SmtpHost = next.SmtpHost
+	m.cfg.SmtpPort = next.SmtpPort
+	m.cfg.SmtpUser = next.SmtpUser
+	m.cfg.SmtpPass = next.SmtpPass
+	m.cfg.SmtpStartTLS = next.SmtpStartTLS
+	m.cfg.SmtpImplicitTLS = next.SmtpImplicitTLS
+	m.cfg.OrgName = next.OrgName
+	m.cfg.CORSAllowOrigins = next.CORSAllowOrigins
+	m.cfg.MailConcurrency = next.MailConcurrency
+}
+
+// Manager holds the live Config and, when loaded from a file, watches it
+// for changes.
+type Manager struct {
+	v   *viper.Viper
+	mu  sync.RWMutex
+	cfg Config
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("github_auth_mode", "pat")
+	v.SetDefault("smtp_starttls", true)
+	v.SetDefault("mail_concurrency", 5)
+	v.SetDefault("mail_max_attempts", 3)
+	v.SetDefault("mail_retry_backoff_seconds", 2)
+	v.SetDefault("oidc_admin_group", "mail-admins")
+	v.SetDefault("session_ttl_seconds", 3600)
+	v.SetDefault("session_cookie_secure", true)
+	v.SetDefault("db_driver", "sqlite3")
+	v.SetDefault("db_dsn", "mail_jobs.db")
+	v.SetDefault("job_poll_interval_seconds", 5)
+}
+
+// Load reads configuration from configPath (YAML or TOML, detected from its
+// extension) if given, layers environment variables on top (e.g. SMTP_HOST
+// overrides smtp_host from the file), and validates the result. It returns
+// an error that lists every missing required field rather than stopping at
+// the first one, so a misconfigured deployment can be fixed in one pass.
+func Load(configPath string) (*Manager, error) {
+	v := viper.New()
+	setDefaults(v)
+	v.AutomaticEnv()
+	// AutomaticEnv alone only affects keys viper already knows about (from
+	// the file or a default), so bind every field's env var explicitly to
+	// let required settings come from the environment alone.
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			if err := v.BindEnv(key); err != nil {
+				return nil, fmt.Errorf("bind env %s: %w", key, err)
+			}
+		}
+	}
+
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read config file %s: %w", configPath, err)
+		}
+	} else {
+		v.SetConfigName("config")
+		v.AddConfigPath(".")
+		if err := v.ReadInConfig(); err != nil {
+			var notFound viper.ConfigFileNotFoundError
+			if !errors.As(err, &notFound) {
+				return nil, fmt.Errorf("read config file: %w", err)
+			}
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Manager{v: v, cfg: cfg}, nil
+}
+
+// Current returns a snapshot of the live config, safe to call concurrently
+// with a reload.
+func (m *Manager) Current() Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cfg
+}
+
+// Watch re-reads the config file whenever it changes on disk, applies the
+// hot-reloadable fields (see applyHotReloadableFields), and invokes
+// onChange with the resulting snapshot. It's a no-op when Load wasn't given
+// a config file to watch. A reload that fails to unmarshal is dropped
+// silently: nothing is logged, onChange is not called, and the previous,
+// already-validated config stays in place.
+func (m *Manager) Watch(onChange func(Config)) {
+	if m.v.ConfigFileUsed() == "" {
+		return
+	}
+
+	m.v.OnConfigChange(func(fsnotify.Event) {
+		var next Config
+		if err := m.v.Unmarshal(&next); err != nil {
+			return
+		}
+
+		m.mu.Lock()
+		m.applyHotReloadableFields(next)
+		current := m.cfg
+		m.mu.Unlock()
+
+		onChange(current)
+	})
+	m.v.WatchConfig()
+}
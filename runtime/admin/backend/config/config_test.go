@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testYAML = `
+github_auth_mode: pat
+github_token: ghp_token
+github_org: acme
+from_email: mail@acme.test
+smtp_host: smtp.acme.test
+oidc_issuer_url: https://issuer.acme.test
+oidc_client_id: client-id
+oidc_client_secret: client-secret
+oidc_redirect_url: https://admin.acme.test/callback
+session_secret: session-secret
+`
+
+func writeTestConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write test config: %s", err)
+	}
+	return path
+}
+
+func TestLoadFromFileAppliesDefaults(t *testing.T) {
+	path := writeTestConfig(t, testYAML)
+
+	mgr, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	cfg := mgr.Current()
+
+	if cfg.DBDriver != "sqlite3" {
+		t.Errorf("DBDriver = %q, want default %q", cfg.DBDriver, "sqlite3")
+	}
+	if cfg.MailConcurrency != 5 {
+		t.Errorf("MailConcurrency = %d, want default 5", cfg.MailConcurrency)
+	}
+	if cfg.OrgName != "acme" {
+		t.Errorf("OrgName = %q, want %q", cfg.OrgName, "acme")
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	path := writeTestConfig(t, testYAML)
+	t.Setenv("SMTP_HOST", "smtp.override.test")
+
+	mgr, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if got := mgr.Current().SmtpHost; got != "smtp.override.test" {
+		t.Errorf("SmtpHost = %q, want the env override %q", got, "smtp.override.test")
+	}
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	path := writeTestConfig(t, "github_org: acme\n")
+	if _, err := Load(path); err == nil {
+		t.Error("expected Load to reject a config missing required fields")
+	}
+}
+
+func TestDurationHelpers(t *testing.T) {
+	cfg := Config{MailRetryBackoffSeconds: 2, SessionTTLSeconds: 3600, JobPollIntervalSeconds: 5}
+
+	if cfg.MailRetryBackoff() != 2*time.Second {
+		t.Errorf("MailRetryBackoff() = %s, want 2s", cfg.MailRetryBackoff())
+	}
+	if cfg.SessionTTL() != time.Hour {
+		t.Errorf("SessionTTL() = %s, want 1h", cfg.SessionTTL())
+	}
+	if cfg.JobPollInterval() != 5*time.Second {
+		t.Errorf("JobPollInterval() = %s, want 5s", cfg.JobPollInterval())
+	}
+}
+
+func TestApplyHotReloadableFieldsOnlyTouchesDocumentedFields(t *testing.T) {
+	mgr := &Manager{cfg: validConfig()}
+	mgr.cfg.SmtpHost = "old-host"
+	mgr.cfg.OidcClientSecret = "old-secret"
+
+	next := validConfig()
+	next.SmtpHost = "new-host"
+	next.OidcClientSecret = "new-secret" // not hot-reloadable: must not apply
+
+	mgr.applyHotReloadableFields(next)
+
+	if mgr.cfg.SmtpHost != "new-host" {
+		t.Errorf("SmtpHost = %q, want the reloaded value %q", mgr.cfg.SmtpHost, "new-host")
+	}
+	if mgr.cfg.OidcClientSecret != "old-secret" {
+		t.Errorf("OidcClientSecret = %q, want it left untouched by a hot reload", mgr.cfg.OidcClientSecret)
+	}
+}
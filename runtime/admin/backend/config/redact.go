@@ -0,0 +1,71 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+const redacted = "REDACTED"
+
+// Redacted returns a loggable view of c with every secret-bearing field
+// replaced, so the config can be dumped at startup for debugging without
+// leaking credentials into log aggregators.
+func (c Config) Redacted() map[string]any {
+	return map[string]any{
+		"github_auth_mode":           c.GithubAuthMode,
+		"github_token":               redactNonEmpty(c.GithubToken),
+		"github_app_id":              c.GithubAppID,
+		"github_app_installation_id": c.GithubAppInstallationID,
+		"github_app_private_key":     redactNonEmpty(c.GithubAppPrivateKey),
+		"github_org":                 c.OrgName,
+
+		"smtp_host":         c.SmtpHost,
+		"smtp_port":         c.SmtpPort,
+		"smtp_user":         c.SmtpUser,
+		"smtp_pass":         redactNonEmpty(c.SmtpPass),
+		"from_email":        c.FromEmail,
+		"smtp_starttls":     c.SmtpStartTLS,
+		"smtp_implicit_tls": c.SmtpImplicitTLS,
+
+		"mail_concurrency":           c.MailConcurrency,
+		"mail_max_attempts":          c.MailMaxAttempts,
+		"mail_retry_backoff_seconds": c.MailRetryBackoffSeconds,
+
+		"oidc_issuer_url":    c.OidcIssuerURL,
+		"oidc_client_id":     c.OidcClientID,
+		"oidc_client_secret": redactNonEmpty(c.OidcClientSecret),
+		"oidc_redirect_url":  c.OidcRedirectURL,
+		"oidc_admin_group":   c.OidcAdminGroup,
+
+		"session_secret":        redactNonEmpty(c.SessionSecret),
+		"session_ttl_seconds":   c.SessionTTLSeconds,
+		"session_cookie_secure": c.SessionCookieSecure,
+
+		"db_driver":                 c.DBDriver,
+		"db_dsn":                    redactNonEmpty(c.DBDSN),
+		"job_poll_interval_seconds": c.JobPollIntervalSeconds,
+
+		"cors_allow_origins": c.CORSAllowOrigins,
+	}
+}
+
+func redactNonEmpty(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate checks that every field main needs to start is present, and
+// returns a single error listing all of them so a misconfigured deployment
+// doesn't have to be fixed one field at a time.
+func (c Config) Validate() error {
+	var missing []string
+	require := func(ok bool, field string) {
+		if !ok {
+			missing = append(missing, field)
+		}
+	}
+
+	require(c.OrgName != "", "github_org")
+	require(c.FromEmail != "", "from_email")
+	require(c.SmtpHost != "", "smtp_host")
+	require(c.OidcIssuerURL != "", "oidc_issuer_url")
+	require(c.OidcClientID != "", "oidc_client_id")
+	require(c.OidcClientSecret != "", "oidc_client_secret")
+	require(c.OidcRedirectURL != "", "oidc_redirect_url")
+	require(c.SessionSecret != "", "session_secret")
+	require(c.DBDriver != "", "db_driver")
+	require(c.DBDSN != "", "db_dsn")
+
+	switch c.GithubAuthMode {
+	case "pat", "":
+		require(c.GithubToken != "", "github_token")
+	case "app":
+		require(c.GithubAppID != 0, "github_app_id")
+		require(c.GithubAppInstallationID != 0, "github_app_installation_id")
+		require(c.GithubAppPrivateKeyPath != "" || c.GithubAppPrivateKey != "", "github_app_private_key or github_app_private_key_path")
+	default:
+		missing = append(missing, fmt.Sprintf("github_auth_mode must be \"pat\" or \"app\", got %q", c.GithubAuthMode))
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("config: missing or invalid: %s", strings.Join(missing, ", "))
+}
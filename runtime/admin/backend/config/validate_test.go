@@ -0,0 +1,101 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func validConfig() Config {
+	return Config{
+		OrgName:          "acme",
+		FromEmail:        "mail@acme.test",
+		SmtpHost:         "smtp.acme.test",
+		OidcIssuerURL:    "https://issuer.acme.test",
+		OidcClientID:     "client-id",
+		OidcClientSecret: "client-secret",
+		OidcRedirectURL:  "https://admin.acme.test/callback",
+		SessionSecret:    "session-secret",
+		DBDriver:         "sqlite3",
+		DBDSN:            "mail_jobs.db",
+		GithubAuthMode:   "pat",
+		GithubToken:      "ghp_token",
+	}
+}
+
+func TestValidateAcceptsCompleteConfig(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateReportsAllMissingFields(t *testing.T) {
+	err := Config{}.Validate()
+	if err == nil {
+		t.Fatal("expected Validate to reject a zero-value config")
+	}
+	for _, field := range []string{"github_org", "from_email", "smtp_host", "oidc_issuer_url", "session_secret", "db_driver", "db_dsn"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error %q does not mention missing field %q", err, field)
+		}
+	}
+}
+
+func TestValidateDefaultsGithubAuthModeToPAT(t *testing.T) {
+	cfg := validConfig()
+	cfg.GithubAuthMode = ""
+	cfg.GithubToken = ""
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "github_token") {
+		t.Errorf("Validate() = %v, want it to require github_token when github_auth_mode is unset", err)
+	}
+}
+
+func TestValidateGithubAppModeRequiresAppFields(t *testing.T) {
+	cfg := validConfig()
+	cfg.GithubAuthMode = "app"
+	cfg.GithubToken = ""
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected Validate to reject app mode without app credentials")
+	}
+	for _, field := range []string{"github_app_id", "github_app_installation_id"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error %q does not mention missing field %q", err, field)
+		}
+	}
+
+	cfg.GithubAppID = 1
+	cfg.GithubAppInstallationID = 2
+	cfg.GithubAppPrivateKey = "pem-bytes"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil once app fields are set", err)
+	}
+}
+
+func TestValidateRejectsUnknownAuthMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.GithubAuthMode = "oauth"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected Validate to reject an unknown github_auth_mode")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package githubclient
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-github/v72/github"
+)
+
+// appTokenExpiryMargin is how far ahead of actual expiry a cached
+// installation token is treated as stale, so a request in flight doesn't
+// race a token that expires mid-call.
+const appTokenExpiryMargin = time.Minute
+
+// refreshInstallationToken exchanges c's app credentials for a new
+// installation access token if the cached one is missing or near expiry.
+// It's a no-op otherwise, so repeated calls are cheap.
+func (c *Client) refreshInstallationToken(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gh != nil && time.Now().Before(c.tokenExpiry.Add(-appTokenExpiryMargin)) {
+		return nil
+	}
+
+	appJWT, err := buildAppJWT(c.cfg.AppID, c.privateKey)
+	if err != nil {
+		return fmt.Errorf("sign app jwt: %w", err)
+	}
+
+	appClient := github.NewClient(nil).WithAuthToken(appJWT)
+	token, _, err := appClient.Apps.CreateInstallationToken(ctx, c.cfg.InstallationID, nil)
+	if err != nil {
+		return fmt.Errorf("create installation token: %w", err)
+	}
+
+	c.gh = github.NewClient(nil).WithAuthToken(token.GetToken())
+	c.tokenExpiry = token.GetExpiresAt().Time
+	return nil
+}
+
+// buildAppJWT signs the short-lived JWT a GitHub App uses to authenticate
+// as itself (as opposed to as one of its installations) when requesting an
+// installation token.
+func buildAppJWT(appID int64, key *rsa.PrivateKey) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    strconv.FormatInt(appID, 10),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-30 * time.Second)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+}
+
+// parsePrivateKey decodes a PEM-encoded RSA private key, accepting both the
+// PKCS#1 and PKCS#8 encodings GitHub App keys are commonly downloaded in.
+func parsePrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse PKCS8 key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not RSA")
+	}
+	return rsaKey, nil
+}
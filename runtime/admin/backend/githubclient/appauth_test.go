@@ -0,0 +1,126 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package githubclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strconv"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %s", err)
+	}
+	return key
+}
+
+func TestParsePrivateKeyPKCS1(t *testing.T) {
+	key := testRSAKey(t)
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+
+	got, err := parsePrivateKey(pem.EncodeToMemory(block))
+	if err != nil {
+		t.Fatalf("parsePrivateKey: %s", err)
+	}
+	if !got.Equal(key) {
+		t.Error("parsePrivateKey() did not round-trip the PKCS1 key")
+	}
+}
+
+func TestParsePrivateKeyPKCS8(t *testing.T) {
+	key := testRSAKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %s", err)
+	}
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+
+	got, err := parsePrivateKey(pem.EncodeToMemory(block))
+	if err != nil {
+		t.Fatalf("parsePrivateKey: %s", err)
+	}
+	if !got.Equal(key) {
+		t.Error("parsePrivateKey() did not round-trip the PKCS8 key")
+	}
+}
+
+func TestParsePrivateKeyRejectsNonPEM(t *testing.T) {
+	if _, err := parsePrivateKey([]byte("not a pem block")); err == nil {
+		t.Error("expected parsePrivateKey to reject non-PEM input")
+	}
+}
+
+func TestParsePrivateKeyRejectsNonRSAKey(t *testing.T) {
+	// An ECDSA key is valid PKCS8 but not the RSA type GitHub App keys use.
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: ecdsaPKCS8DER(t)}
+	if _, err := parsePrivateKey(pem.EncodeToMemory(block)); err == nil {
+		t.Error("expected parsePrivateKey to reject a non-RSA key")
+	}
+}
+
+func TestBuildAppJWTIsVerifiableAndCarriesAppID(t *testing.T) {
+	key := testRSAKey(t)
+	const appID = int64(12345)
+
+	token, err := buildAppJWT(appID, key)
+	if err != nil {
+		t.Fatalf("buildAppJWT: %s", err)
+	}
+
+	parsed, err := jwt.ParseWithClaims(token, &jwt.RegisteredClaims{}, func(*jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	})
+	if err != nil {
+		t.Fatalf("parse signed jwt: %s", err)
+	}
+	claims, ok := parsed.Claims.(*jwt.RegisteredClaims)
+	if !ok || !parsed.Valid {
+		t.Fatalf("unexpected claims type or invalid token: %+v", parsed.Claims)
+	}
+	if claims.Issuer != strconv.FormatInt(appID, 10) {
+		t.Errorf("Issuer = %q, want %q", claims.Issuer, strconv.FormatInt(appID, 10))
+	}
+	if !claims.ExpiresAt.After(claims.IssuedAt.Time) {
+		t.Error("expected ExpiresAt to be after IssuedAt")
+	}
+}
+
+func ecdsaPKCS8DER(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ecdsa key: %s", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %s", err)
+	}
+	return der
+}
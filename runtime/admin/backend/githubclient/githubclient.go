@@ -0,0 +1,158 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package githubclient wraps go-github with the auth and resilience the
+// admin API needs: a single long-lived client reused across requests
+// (instead of one built per handler call), either a personal access token
+// or a GitHub App installation token (refreshed and cached until it's near
+// expiry), and a shared rate limiter that backs off when the API reports
+// it's exhausted instead of letting every caller hit it at once.
+package githubclient
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// AuthMode selects how Client authenticates to the GitHub API.
+type AuthMode string
+
+const (
+	// AuthModePAT authenticates every request with a single long-lived
+	// personal access token.
+	AuthModePAT AuthMode = "pat"
+
+	// AuthModeApp authenticates as a GitHub App installation: a private
+	// key signs short-lived JWTs that are exchanged for installation
+	// access tokens, which Client refreshes automatically before expiry.
+	AuthModeApp AuthMode = "app"
+)
+
+// Config configures a Client's authentication.
+type Config struct {
+	Mode AuthMode
+
+	// Token is the personal access token used when Mode is AuthModePAT.
+	Token string
+
+	// AppID, InstallationID and PrivateKeyPEM authenticate as a GitHub
+	// App installation when Mode is AuthModeApp. PrivateKeyPEM is the
+	// app's private key in PEM format, as downloaded from the app's
+	// settings page.
+	AppID          int64
+	InstallationID int64
+	PrivateKeyPEM  []byte
+}
+
+// RateLimitError is returned when the GitHub API rejects a call because its
+// quota is exhausted (or because of a secondary/abuse rate limit).
+// RetryAfter is how long until the limit is expected to lift; the Client's
+// shared limiter makes later calls wait that long before trying again.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("github: rate limited, retry after %s: %s", e.RetryAfter, e.Err)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Client is a reusable, authenticated GitHub API client.
+type Client struct {
+	cfg        Config
+	privateKey *rsa.PrivateKey
+	limiter    limiter
+
+	mu          sync.Mutex
+	gh          *github.Client
+	tokenExpiry time.Time
+
+	etagMu sync.Mutex
+	etags  map[string]etagEntry
+}
+
+// NewClient builds a Client authenticated according to cfg. For
+// AuthModeApp it exchanges the app's private key for an initial
+// installation token before returning, so construction fails fast on a
+// bad key or installation ID rather than on the first request.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	c := &Client{cfg: cfg, etags: make(map[string]etagEntry)}
+
+	switch cfg.Mode {
+	case AuthModeApp:
+		key, err := parsePrivateKey(cfg.PrivateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse app private key: %w", err)
+		}
+		c.privateKey = key
+		if err := c.refreshInstallationToken(ctx); err != nil {
+			return nil, err
+		}
+	case AuthModePAT, "":
+		c.cfg.Mode = AuthModePAT
+		c.gh = github.NewClient(nil).WithAuthToken(cfg.Token)
+	default:
+		return nil, fmt.Errorf("githubclient: unknown auth mode %q", cfg.Mode)
+	}
+
+	return c, nil
+}
+
+// client returns the underlying go-github client, refreshing the
+// installation token first if it's a GitHub App client nearing expiry.
+func (c *Client) client(ctx context.Context) (*github.Client, error) {
+	if c.cfg.Mode != AuthModeApp {
+		return c.gh, nil
+	}
+	if err := c.refreshInstallationToken(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gh, nil
+}
+
+// GetUser fetches a single user's profile.
+func (c *Client) GetUser(ctx context.Context, login string) (*github.User, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	gh, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, resp, err := gh.Users.Get(ctx, login)
+	c.limiter.note(resp)
+	if rlErr := asRateLimitError(err); rlErr != nil {
+		c.limiter.backoff(time.Now().Add(rlErr.RetryAfter))
+		return nil, rlErr
+	}
+	return user, err
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package githubclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// etagEntry caches one page of ListMembers results keyed by its ETag, so a
+// conditional request that comes back 304 Not Modified can be served
+// without re-fetching or re-decoding the page.
+type etagEntry struct {
+	etag    string
+	members []*github.User
+}
+
+// ListMembers returns every member of org, walking all pages rather than
+// just the first. Each page is requested conditionally: if the page is
+// unchanged since the last call, GitHub returns 304 Not Modified and the
+// cached copy is reused instead of re-downloading it.
+func (c *Client) ListMembers(ctx context.Context, org string) ([]*github.User, error) {
+	gh, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*github.User
+	url := fmt.Sprintf("orgs/%s/members?per_page=100", org)
+
+	for url != "" {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := gh.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("build members request: %w", err)
+		}
+
+		cacheKey := req.URL.String()
+		c.etagMu.Lock()
+		cached, hasCache := c.etags[cacheKey]
+		c.etagMu.Unlock()
+		if hasCache {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+
+		var page []*github.User
+		resp, err := gh.Do(ctx, req, &page)
+		c.limiter.note(resp)
+
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			c.limiter.backoff(time.Now().Add(rlErr.RetryAfter))
+			return nil, rlErr
+		}
+
+		switch {
+		case resp != nil && resp.StatusCode == http.StatusNotModified:
+			all = append(all, cached.members...)
+		case err != nil:
+			return nil, fmt.Errorf("list org members: %w", err)
+		default:
+			if etag := resp.Header.Get("ETag"); etag != "" {
+				c.etagMu.Lock()
+				c.etags[cacheKey] = etagEntry{etag: etag, members: page}
+				c.etagMu.Unlock()
+			}
+			all = append(all, page...)
+		}
+
+		url = ""
+		if resp != nil && resp.NextPage != 0 {
+			url = fmt.Sprintf("orgs/%s/members?per_page=100&page=%d", org, resp.NextPage)
+		}
+	}
+
+	return all, nil
+}
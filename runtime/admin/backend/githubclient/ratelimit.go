@@ -0,0 +1,101 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package githubclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// limiter holds requests back during a cooldown learned from the API's
+// own rate limit headers, so one caller's exhausted quota makes every
+// other caller sharing this Client wait instead of also getting rejected.
+type limiter struct {
+	mu       sync.Mutex
+	resumeAt time.Time
+}
+
+// wait blocks until any previously observed cooldown has elapsed, or
+// returns ctx's error if it's cancelled first.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	resumeAt := l.resumeAt
+	l.mu.Unlock()
+
+	d := time.Until(resumeAt)
+	if d <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// note records the rate limit window reported by resp, so the next call
+// to wait blocks once the quota is exhausted rather than after.
+func (l *limiter) note(resp *github.Response) {
+	if resp == nil || resp.Rate.Remaining > 0 || resp.Rate.Reset.IsZero() {
+		return
+	}
+	l.backoff(resp.Rate.Reset.Time)
+}
+
+// backoff extends the cooldown to until, if it isn't already later.
+func (l *limiter) backoff(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.resumeAt) {
+		l.resumeAt = until
+	}
+}
+
+// asRateLimitError recognizes the primary and secondary (abuse) rate limit
+// errors go-github returns and converts them to our own RateLimitError, so
+// callers outside this package don't need to import go-github just to
+// detect the condition.
+func asRateLimitError(err error) *RateLimitError {
+	if err == nil {
+		return nil
+	}
+
+	var primary *github.RateLimitError
+	if errors.As(err, &primary) {
+		return &RateLimitError{RetryAfter: time.Until(primary.Rate.Reset.Time), Err: err}
+	}
+
+	var abuse *github.AbuseRateLimitError
+	if errors.As(err, &abuse) {
+		retryAfter := time.Minute
+		if abuse.RetryAfter != nil {
+			retryAfter = *abuse.RetryAfter
+		}
+		return &RateLimitError{RetryAfter: retryAfter, Err: err}
+	}
+
+	return nil
+}
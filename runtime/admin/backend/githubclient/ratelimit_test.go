@@ -0,0 +1,143 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package githubclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+)
+
+func TestLimiterWaitReturnsImmediatelyWithoutCooldown(t *testing.T) {
+	var l limiter
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("wait: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("wait() took %s with no cooldown set, want near-instant", elapsed)
+	}
+}
+
+func TestLimiterWaitBlocksUntilCooldownElapses(t *testing.T) {
+	var l limiter
+	l.backoff(time.Now().Add(50 * time.Millisecond))
+
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("wait: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("wait() returned after %s, want it to block roughly until the cooldown", elapsed)
+	}
+}
+
+func TestLimiterWaitReturnsContextError(t *testing.T) {
+	var l limiter
+	l.backoff(time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.wait(ctx); err == nil {
+		t.Error("expected wait to return ctx's error once it's cancelled")
+	}
+}
+
+func TestLimiterBackoffOnlyExtendsCooldown(t *testing.T) {
+	var l limiter
+	later := time.Now().Add(time.Hour)
+	l.backoff(later)
+	l.backoff(time.Now().Add(time.Minute)) // earlier than later: must not shorten the cooldown
+
+	if !l.resumeAt.Equal(later) {
+		t.Errorf("resumeAt = %s, want unchanged %s", l.resumeAt, later)
+	}
+}
+
+func TestLimiterNoteIgnoresRemainingQuota(t *testing.T) {
+	var l limiter
+	l.note(&github.Response{Rate: github.Rate{Remaining: 10, Reset: github.Timestamp{Time: time.Now().Add(time.Hour)}}})
+	if !l.resumeAt.IsZero() {
+		t.Errorf("resumeAt = %s, want zero value since quota wasn't exhausted", l.resumeAt)
+	}
+}
+
+func TestLimiterNoteBacksOffWhenExhausted(t *testing.T) {
+	var l limiter
+	reset := time.Now().Add(time.Hour)
+	l.note(&github.Response{Rate: github.Rate{Remaining: 0, Reset: github.Timestamp{Time: reset}}})
+	if !l.resumeAt.Equal(reset) {
+		t.Errorf("resumeAt = %s, want %s", l.resumeAt, reset)
+	}
+}
+
+func TestAsRateLimitErrorNil(t *testing.T) {
+	if err := asRateLimitError(nil); err != nil {
+		t.Errorf("asRateLimitError(nil) = %v, want nil", err)
+	}
+}
+
+func TestAsRateLimitErrorNonRateLimit(t *testing.T) {
+	if err := asRateLimitError(errors.New("boom")); err != nil {
+		t.Errorf("asRateLimitError() = %v, want nil for an unrelated error", err)
+	}
+}
+
+func TestAsRateLimitErrorPrimary(t *testing.T) {
+	reset := time.Now().Add(time.Minute)
+	err := &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: reset}}}
+
+	rlErr := asRateLimitError(err)
+	if rlErr == nil {
+		t.Fatal("expected a non-nil RateLimitError")
+	}
+	if rlErr.RetryAfter <= 0 || rlErr.RetryAfter > time.Minute {
+		t.Errorf("RetryAfter = %s, want roughly up to a minute", rlErr.RetryAfter)
+	}
+}
+
+func TestAsRateLimitErrorAbuseWithRetryAfter(t *testing.T) {
+	retryAfter := 30 * time.Second
+	err := &github.AbuseRateLimitError{RetryAfter: &retryAfter}
+
+	rlErr := asRateLimitError(err)
+	if rlErr == nil {
+		t.Fatal("expected a non-nil RateLimitError")
+	}
+	if rlErr.RetryAfter != retryAfter {
+		t.Errorf("RetryAfter = %s, want %s", rlErr.RetryAfter, retryAfter)
+	}
+}
+
+func TestAsRateLimitErrorAbuseWithoutRetryAfter(t *testing.T) {
+	err := &github.AbuseRateLimitError{}
+
+	rlErr := asRateLimitError(err)
+	if rlErr == nil {
+		t.Fatal("expected a non-nil RateLimitError")
+	}
+	if rlErr.RetryAfter != time.Minute {
+		t.Errorf("RetryAfter = %s, want the default 1 minute", rlErr.RetryAfter)
+	}
+}
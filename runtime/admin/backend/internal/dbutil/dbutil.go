@@ -0,0 +1,97 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package dbutil holds the bits of database/sql plumbing shared by every
+// package that implements its Store on top of SQLite and Postgres (jobs,
+// templates): id generation, scanning *sql.Row/*sql.Rows through one
+// interface, rebinding "?" placeholders for drivers that don't accept them,
+// and recognizing driver-specific error conditions like a unique-constraint
+// violation.
+package dbutil
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// RowScanner is satisfied by both *sql.Row and *sql.Rows, so a single scan
+// helper can back both a QueryRow and a Query call site.
+type RowScanner interface {
+	Scan(dest ...any) error
+}
+
+// NewID returns a random hex-encoded identifier suitable for a primary key.
+func NewID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Rebind rewrites a query written with the "?" placeholders SQLite accepts
+// into whatever driverName actually expects. lib/pq, unlike the sqlite3 and
+// mysql drivers, only accepts its own ordinal markers ($1, $2, ...) and does
+// no rebinding of its own, so every query has to be translated before it
+// reaches a Postgres connection.
+func Rebind(driverName, query string) string {
+	if driverName != "postgres" {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+// IsUniqueViolation reports whether err is a constraint violation from
+// either of the two drivers this backend supports, so a caller can turn a
+// racing INSERT into a clean "already exists" instead of a generic 500. It
+// matches any SQLITE_CONSTRAINT error and any Postgres class 23 (integrity
+// constraint violation) error, so besides unique-constraint and unique-index
+// violations it also reports e.g. NOT NULL or foreign-key failures.
+func IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrConstraint
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Class() == "23"
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dbutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestRebindLeavesNonPostgresUntouched(t *testing.T) {
+	query := `SELECT * FROM mail_jobs WHERE id = ? AND status = ?`
+	for _, driver := range []string{"sqlite3", "mysql", ""} {
+		if got := Rebind(driver, query); got != query {
+			t.Errorf("Rebind(%q, ...) = %q, want unchanged %q", driver, got, query)
+		}
+	}
+}
+
+func TestRebindPostgresOrdinals(t *testing.T) {
+	query := `UPDATE mail_jobs SET status = ?, updated_at = ? WHERE id = ? AND status = ?`
+	want := `UPDATE mail_jobs SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	if got := Rebind("postgres", query); got != want {
+		t.Errorf("Rebind(postgres, ...) = %q, want %q", got, want)
+	}
+}
+
+func TestRebindPostgresNoPlaceholders(t *testing.T) {
+	query := `DELETE FROM mail_templates`
+	if got := Rebind("postgres", query); got != query {
+		t.Errorf("Rebind(postgres, ...) = %q, want unchanged %q", got, query)
+	}
+}
+
+func TestIsUniqueViolationRecognizesSqliteConstraintError(t *testing.T) {
+	err := sqlite3.Error{Code: sqlite3.ErrConstraint}
+	if !IsUniqueViolation(err) {
+		t.Error("expected IsUniqueViolation to recognize a sqlite3 constraint error")
+	}
+}
+
+func TestIsUniqueViolationRejectsUnrelatedErrors(t *testing.T) {
+	if IsUniqueViolation(errors.New("boom")) {
+		t.Error("expected IsUniqueViolation to reject an unrelated error")
+	}
+}
+
+func TestNewIDIsUnique(t *testing.T) {
+	a, err := NewID()
+	if err != nil {
+		t.Fatalf("NewID: %s", err)
+	}
+	b, err := NewID()
+	if err != nil {
+		t.Fatalf("NewID: %s", err)
+	}
+	if a == b {
+		t.Error("expected two NewID calls to differ")
+	}
+	if len(a) != 32 {
+		t.Errorf("expected a 32-char hex id, got %d chars: %q", len(a), a)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package jobs turns a bulk mail send into a persisted, resumable campaign:
+// a MailJob queued through a Store and worked off by a Worker, instead of a
+// send that blocks the HTTP request until every recipient is done.
+package jobs
+
+import "time"
+
+// Status is the lifecycle state of a MailJob.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+	StatusFailed    Status = "failed"
+)
+
+// RecipientStatus is the delivery state of one recipient within a MailJob.
+type RecipientStatus string
+
+const (
+	RecipientQueued   RecipientStatus = "queued"
+	RecipientSent     RecipientStatus = "sent"
+	RecipientBounced  RecipientStatus = "bounced"
+	RecipientFailed   RecipientStatus = "failed"
+	RecipientRetrying RecipientStatus = "retrying"
+)
+
+// MailJob is a campaign queued for the background Worker to send.
+type MailJob struct {
+	ID              string
+	IdempotencyKey  string
+	TemplateID      string
+	Subject         string
+	Body            string
+	TemplateVars    map[string]string
+	RecipientFilter string
+	ScheduledAt     *time.Time
+	Status          Status
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Recipient is one member's delivery record within a MailJob.
+type Recipient struct {
+	JobID     string
+	Email     string
+	Login     string
+	Name      string
+	Status    RecipientStatus
+	Attempts  int
+	LastError string
+	UpdatedAt time.Time
+}
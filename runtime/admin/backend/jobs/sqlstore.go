@@ -0,0 +1,303 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/internal/dbutil"
+)
+
+// SQLStore is a Store backed by database/sql. It was written against and is
+// exercised with SQLite and Postgres; both speak the subset of SQL used
+// here (including partial unique indexes), but lib/pq only accepts its own
+// "$1, $2, ..." placeholders, so every query is rebound for driverName
+// before it runs (see dbutil.Rebind).
+type SQLStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLStore wraps db and ensures the jobs schema exists, creating it if
+// this is a fresh database. driverName is the name db was opened with
+// (e.g. "sqlite3" or "postgres") and determines how queries are rebound.
+func NewSQLStore(ctx context.Context, db *sql.DB, driverName string) (*SQLStore, error) {
+	s := &SQLStore{db: db, driver: driverName}
+	if err := s.migrate(ctx); err != nil {
+		return nil, fmt.Errorf("migrate jobs schema: %w", err)
+	}
+	return s, nil
+}
+
+func (s *SQLStore) rebind(query string) string {
+	return dbutil.Rebind(s.driver, query)
+}
+
+func (s *SQLStore) migrate(ctx context.Context) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS mail_jobs (
+			id               TEXT PRIMARY KEY,
+			idempotency_key  TEXT,
+			template_id      TEXT,
+			subject          TEXT NOT NULL,
+			body             TEXT NOT NULL,
+			template_vars    TEXT,
+			recipient_filter TEXT,
+			scheduled_at     TIMESTAMP,
+			status           TEXT NOT NULL,
+			created_at       TIMESTAMP NOT NULL,
+			updated_at       TIMESTAMP NOT NULL
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_mail_jobs_idempotency_key
+			ON mail_jobs (idempotency_key) WHERE idempotency_key != ''`,
+		`CREATE TABLE IF NOT EXISTS mail_job_recipients (
+			job_id     TEXT NOT NULL,
+			email      TEXT NOT NULL,
+			login      TEXT,
+			name       TEXT,
+			status     TEXT NOT NULL,
+			attempts   INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT,
+			updated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (job_id, email)
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) CreateJob(ctx context.Context, job *MailJob) error {
+	id, err := dbutil.NewID()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	vars, err := json.Marshal(job.TemplateVars)
+	if err != nil {
+		return fmt.Errorf("marshal template vars: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, s.rebind(`
+		INSERT INTO mail_jobs
+			(id, idempotency_key, template_id, subject, body, template_vars, recipient_filter, scheduled_at, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		id, job.IdempotencyKey, job.TemplateID, job.Subject, job.Body, string(vars),
+		job.RecipientFilter, job.ScheduledAt, StatusQueued, now, now,
+	)
+	if err != nil {
+		if dbutil.IsUniqueViolation(err) {
+			return ErrDuplicateIdempotencyKey
+		}
+		return fmt.Errorf("insert mail job: %w", err)
+	}
+
+	job.ID = id
+	job.Status = StatusQueued
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	return nil
+}
+
+func (s *SQLStore) JobByIdempotencyKey(ctx context.Context, key string) (*MailJob, error) {
+	row := s.db.QueryRowContext(ctx, s.rebind(`
+		SELECT id, idempotency_key, template_id, subject, body, template_vars, recipient_filter, scheduled_at, status, created_at, updated_at
+		FROM mail_jobs WHERE idempotency_key = ?`), key)
+	return scanJob(row)
+}
+
+func (s *SQLStore) GetJob(ctx context.Context, id string) (*MailJob, error) {
+	row := s.db.QueryRowContext(ctx, s.rebind(`
+		SELECT id, idempotency_key, template_id, subject, body, template_vars, recipient_filter, scheduled_at, status, created_at, updated_at
+		FROM mail_jobs WHERE id = ?`), id)
+	return scanJob(row)
+}
+
+func (s *SQLStore) ListJobs(ctx context.Context) ([]*MailJob, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, idempotency_key, template_id, subject, body, template_vars, recipient_filter, scheduled_at, status, created_at, updated_at
+		FROM mail_jobs ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list mail jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*MailJob
+	for rows.Next() {
+		job, err := scanJobRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (s *SQLStore) UpdateJobStatus(ctx context.Context, id string, status Status) error {
+	res, err := s.db.ExecContext(ctx, s.rebind(`UPDATE mail_jobs SET status = ?, updated_at = ? WHERE id = ?`), status, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("update mail job status: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// LeaseNextJob claims the oldest due, queued job with an optimistic
+// compare-and-swap UPDATE so two worker instances never both pick it up.
+func (s *SQLStore) LeaseNextJob(ctx context.Context, now time.Time) (*MailJob, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`
+		SELECT id FROM mail_jobs
+		WHERE status = ? AND (scheduled_at IS NULL OR scheduled_at <= ?)
+		ORDER BY created_at ASC LIMIT 10`), StatusQueued, now)
+	if err != nil {
+		return nil, fmt.Errorf("find queued jobs: %w", err)
+	}
+	var candidates []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range candidates {
+		res, err := s.db.ExecContext(ctx, s.rebind(`UPDATE mail_jobs SET status = ?, updated_at = ? WHERE id = ? AND status = ?`),
+			StatusRunning, time.Now(), id, StatusQueued)
+		if err != nil {
+			return nil, fmt.Errorf("lease mail job: %w", err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 1 {
+			return s.GetJob(ctx, id)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *SQLStore) CreateRecipients(ctx context.Context, jobID string, recipients []*Recipient) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, r := range recipients {
+		status := r.Status
+		if status == "" {
+			status = RecipientQueued
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind(`
+			INSERT INTO mail_job_recipients (job_id, email, login, name, status, attempts, last_error, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+			jobID, r.Email, r.Login, r.Name, status, r.Attempts, r.LastError, now,
+		); err != nil {
+			return fmt.Errorf("insert recipient %s: %w", r.Email, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLStore) ListRecipients(ctx context.Context, jobID string) ([]*Recipient, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`
+		SELECT job_id, email, login, name, status, attempts, last_error, updated_at
+		FROM mail_job_recipients WHERE job_id = ? ORDER BY email ASC`), jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*Recipient
+	for rows.Next() {
+		r := &Recipient{}
+		var lastError sql.NullString
+		if err := rows.Scan(&r.JobID, &r.Email, &r.Login, &r.Name, &r.Status, &r.Attempts, &lastError, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.LastError = lastError.String
+		recipients = append(recipients, r)
+	}
+	return recipients, rows.Err()
+}
+
+func (s *SQLStore) UpdateRecipientStatus(ctx context.Context, jobID, email string, status RecipientStatus, attempts int, lastErr string) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(`
+		UPDATE mail_job_recipients SET status = ?, attempts = ?, last_error = ?, updated_at = ?
+		WHERE job_id = ? AND email = ?`),
+		status, attempts, lastErr, time.Now(), jobID, email,
+	)
+	if err != nil {
+		return fmt.Errorf("update recipient %s: %w", email, err)
+	}
+	return nil
+}
+
+func scanJob(row dbutil.RowScanner) (*MailJob, error) {
+	return scanJobRow(row)
+}
+
+func scanJobRow(row dbutil.RowScanner) (*MailJob, error) {
+	job := &MailJob{}
+	var templateVars string
+	var scheduledAt sql.NullTime
+
+	err := row.Scan(
+		&job.ID, &job.IdempotencyKey, &job.TemplateID, &job.Subject, &job.Body,
+		&templateVars, &job.RecipientFilter, &scheduledAt, &job.Status, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan mail job: %w", err)
+	}
+
+	if templateVars != "" {
+		if err := json.Unmarshal([]byte(templateVars), &job.TemplateVars); err != nil {
+			return nil, fmt.Errorf("unmarshal template vars: %w", err)
+		}
+	}
+	if scheduledAt.Valid {
+		job.ScheduledAt = &scheduledAt.Time
+	}
+
+	return job, nil
+}
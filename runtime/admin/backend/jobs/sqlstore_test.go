@@ -0,0 +1,215 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestStore(t *testing.T) *SQLStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite3: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLStore(context.Background(), db, "sqlite3")
+	if err != nil {
+		t.Fatalf("NewSQLStore: %s", err)
+	}
+	return store
+}
+
+func TestCreateAndGetJob(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	job := &MailJob{IdempotencyKey: "key-1", Subject: "hi", Body: "hello"}
+	if err := store.CreateJob(ctx, job); err != nil {
+		t.Fatalf("CreateJob: %s", err)
+	}
+	if job.ID == "" {
+		t.Fatal("expected CreateJob to assign an ID")
+	}
+	if job.Status != StatusQueued {
+		t.Errorf("expected new job to be StatusQueued, got %s", job.Status)
+	}
+
+	got, err := store.GetJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %s", err)
+	}
+	if got.Subject != job.Subject || got.Body != job.Body {
+		t.Errorf("GetJob() = %+v, want subject/body matching %+v", got, job)
+	}
+
+	byKey, err := store.JobByIdempotencyKey(ctx, "key-1")
+	if err != nil {
+		t.Fatalf("JobByIdempotencyKey: %s", err)
+	}
+	if byKey.ID != job.ID {
+		t.Errorf("JobByIdempotencyKey() returned job %s, want %s", byKey.ID, job.ID)
+	}
+}
+
+func TestCreateJobRejectsDuplicateIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	first := &MailJob{IdempotencyKey: "dup-key", Subject: "first"}
+	if err := store.CreateJob(ctx, first); err != nil {
+		t.Fatalf("CreateJob: %s", err)
+	}
+
+	second := &MailJob{IdempotencyKey: "dup-key", Subject: "second"}
+	err := store.CreateJob(ctx, second)
+	if !errors.Is(err, ErrDuplicateIdempotencyKey) {
+		t.Errorf("CreateJob() error = %v, want ErrDuplicateIdempotencyKey", err)
+	}
+}
+
+func TestGetJobNotFound(t *testing.T) {
+	store := newTestStore(t)
+	if _, err := store.GetJob(context.Background(), "does-not-exist"); err != ErrNotFound {
+		t.Errorf("GetJob() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLeaseNextJobClaimsOldestDueJob(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	first := &MailJob{Subject: "first"}
+	if err := store.CreateJob(ctx, first); err != nil {
+		t.Fatalf("CreateJob: %s", err)
+	}
+	time.Sleep(time.Millisecond)
+	second := &MailJob{Subject: "second"}
+	if err := store.CreateJob(ctx, second); err != nil {
+		t.Fatalf("CreateJob: %s", err)
+	}
+
+	leased, err := store.LeaseNextJob(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("LeaseNextJob: %s", err)
+	}
+	if leased == nil || leased.ID != first.ID {
+		t.Fatalf("LeaseNextJob() = %+v, want the first-created job", leased)
+	}
+	if leased.Status != StatusRunning {
+		t.Errorf("expected leased job to be StatusRunning, got %s", leased.Status)
+	}
+
+	// Leasing again must not return the same job twice.
+	leased2, err := store.LeaseNextJob(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("LeaseNextJob: %s", err)
+	}
+	if leased2 == nil || leased2.ID != second.ID {
+		t.Fatalf("second LeaseNextJob() = %+v, want the second-created job", leased2)
+	}
+}
+
+func TestLeaseNextJobSkipsNotYetScheduled(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	future := time.Now().Add(time.Hour)
+	job := &MailJob{Subject: "later", ScheduledAt: &future}
+	if err := store.CreateJob(ctx, job); err != nil {
+		t.Fatalf("CreateJob: %s", err)
+	}
+
+	leased, err := store.LeaseNextJob(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("LeaseNextJob: %s", err)
+	}
+	if leased != nil {
+		t.Errorf("LeaseNextJob() = %+v, want nil since the job isn't due yet", leased)
+	}
+}
+
+func TestRecipientLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	job := &MailJob{Subject: "hi"}
+	if err := store.CreateJob(ctx, job); err != nil {
+		t.Fatalf("CreateJob: %s", err)
+	}
+
+	recipients := []*Recipient{{Email: "a@example.com"}, {Email: "b@example.com"}}
+	if err := store.CreateRecipients(ctx, job.ID, recipients); err != nil {
+		t.Fatalf("CreateRecipients: %s", err)
+	}
+
+	listed, err := store.ListRecipients(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("ListRecipients: %s", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("ListRecipients() returned %d recipients, want 2", len(listed))
+	}
+	for _, r := range listed {
+		if r.Status != RecipientQueued {
+			t.Errorf("new recipient %s has status %s, want %s", r.Email, r.Status, RecipientQueued)
+		}
+	}
+
+	if err := store.UpdateRecipientStatus(ctx, job.ID, "a@example.com", RecipientSent, 1, ""); err != nil {
+		t.Fatalf("UpdateRecipientStatus: %s", err)
+	}
+	if err := store.UpdateRecipientStatus(ctx, job.ID, "b@example.com", RecipientBounced, 1, "550 no such mailbox"); err != nil {
+		t.Fatalf("UpdateRecipientStatus: %s", err)
+	}
+
+	listed, err = store.ListRecipients(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("ListRecipients: %s", err)
+	}
+	byEmail := map[string]*Recipient{}
+	for _, r := range listed {
+		byEmail[r.Email] = r
+	}
+	if byEmail["a@example.com"].Status != RecipientSent {
+		t.Errorf("a@example.com status = %s, want %s", byEmail["a@example.com"].Status, RecipientSent)
+	}
+	if byEmail["b@example.com"].Status != RecipientBounced {
+		t.Errorf("b@example.com status = %s, want %s", byEmail["b@example.com"].Status, RecipientBounced)
+	}
+	if byEmail["b@example.com"].LastError == "" {
+		t.Error("expected bounced recipient to retain its last error")
+	}
+}
+
+func TestUpdateJobStatusNotFound(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.UpdateJobStatus(context.Background(), "does-not-exist", StatusCompleted); err != ErrNotFound {
+		t.Errorf("UpdateJobStatus() error = %v, want ErrNotFound", err)
+	}
+}
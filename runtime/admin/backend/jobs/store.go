@@ -0,0 +1,64 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Store lookups that find nothing.
+var ErrNotFound = errors.New("jobs: not found")
+
+// ErrDuplicateIdempotencyKey is ErrNotFound's sibling: it's returned by
+// CreateJob when job.IdempotencyKey collides with an existing job's. A
+// caller that already checked JobByIdempotencyKey and saw nothing can still
+// race a concurrent retry of the same submission past that check; this lets
+// it fall back to looking the existing job up instead of failing the
+// request.
+var ErrDuplicateIdempotencyKey = errors.New("jobs: duplicate idempotency key")
+
+// Store persists MailJobs and their per-recipient delivery state. SQLStore
+// is the only implementation today, but the interface is what lets it be
+// backed by either SQLite or Postgres.
+type Store interface {
+	// CreateJob inserts job, assigning CreatedAt/UpdatedAt and leaving it
+	// StatusQueued. It returns ErrDuplicateIdempotencyKey if job.IdempotencyKey
+	// collides with an existing job.
+	CreateJob(ctx context.Context, job *MailJob) error
+
+	// JobByIdempotencyKey returns the job previously created with key, if
+	// any, so callers can treat a retried submission as a no-op.
+	JobByIdempotencyKey(ctx context.Context, key string) (*MailJob, error)
+
+	GetJob(ctx context.Context, id string) (*MailJob, error)
+	ListJobs(ctx context.Context) ([]*MailJob, error)
+	UpdateJobStatus(ctx context.Context, id string, status Status) error
+
+	// LeaseNextJob atomically claims the oldest StatusQueued job whose
+	// ScheduledAt is due by now, marking it StatusRunning, and returns it.
+	// It returns nil, nil when there is no job ready to run.
+	LeaseNextJob(ctx context.Context, now time.Time) (*MailJob, error)
+
+	CreateRecipients(ctx context.Context, jobID string, recipients []*Recipient) error
+	ListRecipients(ctx context.Context, jobID string) ([]*Recipient, error)
+	UpdateRecipientStatus(ctx context.Context, jobID, email string, status RecipientStatus, attempts int, lastErr string) error
+}
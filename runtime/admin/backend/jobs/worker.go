@@ -0,0 +1,196 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/mail"
+)
+
+// RecipientLister resolves the members a MailJob should be sent to. It's
+// supplied by main rather than owned by this package so jobs stays free of
+// any GitHub-specific knowledge.
+type RecipientLister func(ctx context.Context, job *MailJob) ([]*Recipient, error)
+
+// MessageRenderer renders the subject and text/html bodies a recipient
+// should receive for job, e.g. by executing job's template. It's supplied
+// by main so jobs stays free of any templating knowledge.
+type MessageRenderer func(ctx context.Context, job *MailJob, recipient *Recipient) (subject, text, html string, err error)
+
+// Worker polls Store for due jobs and sends them through Mailer.
+type Worker struct {
+	Store          Store
+	Mailer         *mail.Mailer
+	ListRecipients RecipientLister
+	PollInterval   time.Duration
+
+	// Render personalizes each recipient's message. When unset, the job's
+	// raw Subject/Body are sent unchanged to every recipient.
+	Render MessageRenderer
+
+	// CancelPollInterval controls how often a running job's status is
+	// rechecked for cancellation. It defaults to 2s when unset.
+	CancelPollInterval time.Duration
+}
+
+// Run polls for and processes jobs until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *Worker) runOnce(ctx context.Context) {
+	job, err := w.Store.LeaseNextJob(ctx, time.Now())
+	if err != nil {
+		log.Printf("jobs: lease next job: %s", err)
+		return
+	}
+	if job == nil {
+		return
+	}
+	w.process(ctx, job)
+}
+
+func (w *Worker) process(ctx context.Context, job *MailJob) {
+	recipients, err := w.ListRecipients(ctx, job)
+	if err != nil {
+		log.Printf("jobs: list recipients for job %s: %s", job.ID, err)
+		if err := w.Store.UpdateJobStatus(ctx, job.ID, StatusFailed); err != nil {
+			log.Printf("jobs: mark job %s failed: %s", job.ID, err)
+		}
+		return
+	}
+
+	if err := w.Store.CreateRecipients(ctx, job.ID, recipients); err != nil {
+		log.Printf("jobs: record recipients for job %s: %s", job.ID, err)
+		if err := w.Store.UpdateJobStatus(ctx, job.ID, StatusFailed); err != nil {
+			log.Printf("jobs: mark job %s failed: %s", job.ID, err)
+		}
+		return
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go w.watchCancellation(runCtx, cancel, job.ID)
+
+	msgs := make([]mail.Message, 0, len(recipients))
+	sendable := make([]*Recipient, 0, len(recipients))
+	for _, r := range recipients {
+		msg := mail.Message{To: r.Email, Subject: job.Subject, Body: job.Body}
+		if w.Render != nil {
+			subject, text, html, err := w.Render(ctx, job, r)
+			if err != nil {
+				if uerr := w.Store.UpdateRecipientStatus(ctx, job.ID, r.Email, RecipientFailed, r.Attempts+1, err.Error()); uerr != nil {
+					log.Printf("jobs: record render failure for %s: %s", r.Email, uerr)
+				}
+				continue
+			}
+			msg = mail.Message{To: r.Email, Subject: subject, TextBody: text, HTMLBody: html}
+		}
+		msgs = append(msgs, msg)
+		sendable = append(sendable, r)
+	}
+
+	onAttempt := func(msg mail.Message, attempt int, attemptErr error) {
+		if err := w.Store.UpdateRecipientStatus(ctx, job.ID, msg.To, RecipientRetrying, attempt, attemptErr.Error()); err != nil {
+			log.Printf("jobs: record retry for %s: %s", msg.To, err)
+		}
+	}
+	results := w.Mailer.SendMailContextWithProgress(runCtx, msgs, onAttempt)
+
+	status := StatusCompleted
+	for i, res := range results {
+		r := sendable[i]
+		switch {
+		case res.Success:
+			if err := w.Store.UpdateRecipientStatus(ctx, job.ID, r.Email, RecipientSent, res.Attempts, ""); err != nil {
+				log.Printf("jobs: record delivery to %s: %s", r.Email, err)
+			}
+		case res.Permanent:
+			if err := w.Store.UpdateRecipientStatus(ctx, job.ID, r.Email, RecipientBounced, res.Attempts, res.Error.Error()); err != nil {
+				log.Printf("jobs: record bounce for %s: %s", r.Email, err)
+			}
+		default:
+			// Always record what happened, even when runCtx was cancelled
+			// mid-send: a recipient that was never attempted (Error is nil
+			// because the dispatcher stopped handing out work) still needs
+			// its status moved off "queued", and one whose real SMTP
+			// failure raced with the cancellation still has an error worth
+			// keeping.
+			errMsg := "job cancelled before this recipient was attempted"
+			if res.Error != nil {
+				errMsg = res.Error.Error()
+			}
+			if runCtx.Err() != nil {
+				status = StatusCancelled
+			}
+			if err := w.Store.UpdateRecipientStatus(ctx, job.ID, r.Email, RecipientFailed, res.Attempts, errMsg); err != nil {
+				log.Printf("jobs: record failure for %s: %s", r.Email, err)
+			}
+		}
+	}
+
+	if err := w.Store.UpdateJobStatus(ctx, job.ID, status); err != nil {
+		log.Printf("jobs: finalize job %s: %s", job.ID, err)
+	}
+}
+
+// watchCancellation cancels runCtx as soon as jobID's status is observed as
+// StatusCancelled, letting POST /api/jobs/:id/cancel interrupt a send that's
+// already in flight.
+func (w *Worker) watchCancellation(ctx context.Context, cancel context.CancelFunc, jobID string) {
+	interval := w.CancelPollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job, err := w.Store.GetJob(ctx, jobID)
+			if err == nil && job.Status == StatusCancelled {
+				cancel()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jobs
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/mail"
+)
+
+// fakeStore is an in-memory Store sufficient to drive Worker.process in
+// tests, without a real database.
+type fakeStore struct {
+	mu         sync.Mutex
+	job        *MailJob
+	recipients map[string]*Recipient
+}
+
+func newFakeStore(job *MailJob, recipients []*Recipient) *fakeStore {
+	s := &fakeStore{job: job, recipients: map[string]*Recipient{}}
+	for _, r := range recipients {
+		s.recipients[r.Email] = r
+	}
+	return s
+}
+
+func (s *fakeStore) CreateJob(ctx context.Context, job *MailJob) error { return nil }
+func (s *fakeStore) JobByIdempotencyKey(ctx context.Context, key string) (*MailJob, error) {
+	return nil, ErrNotFound
+}
+func (s *fakeStore) GetJob(ctx context.Context, id string) (*MailJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j := *s.job
+	return &j, nil
+}
+func (s *fakeStore) ListJobs(ctx context.Context) ([]*MailJob, error) { return nil, nil }
+func (s *fakeStore) UpdateJobStatus(ctx context.Context, id string, status Status) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.job.Status = status
+	return nil
+}
+func (s *fakeStore) LeaseNextJob(ctx context.Context, now time.Time) (*MailJob, error) {
+	return nil, nil
+}
+func (s *fakeStore) CreateRecipients(ctx context.Context, jobID string, recipients []*Recipient) error {
+	return nil
+}
+func (s *fakeStore) ListRecipients(ctx context.Context, jobID string) ([]*Recipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*Recipient
+	for _, r := range s.recipients {
+		out = append(out, r)
+	}
+	return out, nil
+}
+func (s *fakeStore) UpdateRecipientStatus(ctx context.Context, jobID, email string, status RecipientStatus, attempts int, lastErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.recipients[email]
+	if !ok {
+		r = &Recipient{JobID: jobID, Email: email}
+		s.recipients[email] = r
+	}
+	r.Status = status
+	r.Attempts = attempts
+	r.LastError = lastErr
+	return nil
+}
+
+// storeRecipientLister adapts a fakeStore's already-created recipients into
+// a RecipientLister, standing in for the GitHub-membership lookup main
+// normally supplies.
+func storeRecipientLister(store *fakeStore) RecipientLister {
+	return func(ctx context.Context, job *MailJob) ([]*Recipient, error) {
+		return store.ListRecipients(ctx, job.ID)
+	}
+}
+
+// fakeSMTPServer is a minimal SMTP server for exercising mail.Mailer against
+// a real TCP connection without a real mail host: it accepts EHLO/MAIL/RCPT
+// /DATA/RSET/QUIT and replies per-recipient according to rcptCode.
+type fakeSMTPServer struct {
+	ln       net.Listener
+	rcptCode map[string]int
+}
+
+func startFakeSMTPServer(t *testing.T, rcptCode map[string]int) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %s", err)
+	}
+	s := &fakeSMTPServer{ln: ln, rcptCode: rcptCode}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeSMTPServer) addr() string { return s.ln.Addr().String() }
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	write := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	write("220 fake.test ESMTP ready")
+
+	var rcpt string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		upper := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(upper, "EHLO"):
+			write("250 fake.test")
+		case strings.HasPrefix(upper, "MAIL FROM"):
+			write("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO"):
+			start := strings.Index(line, "<")
+			end := strings.Index(line, ">")
+			if start != -1 && end != -1 {
+				rcpt = line[start+1 : end]
+			}
+			code, ok := s.rcptCode[rcpt]
+			if !ok {
+				code = 250
+			}
+			switch {
+			case code >= 500:
+				write("550 no such mailbox")
+			case code >= 400:
+				write("450 mailbox busy")
+			default:
+				write("250 OK")
+			}
+		case strings.HasPrefix(upper, "DATA"):
+			write("354 go ahead")
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if strings.TrimRight(dataLine, "\r\n") == "." {
+					break
+				}
+			}
+			write("250 OK queued")
+		case strings.HasPrefix(upper, "RSET"):
+			write("250 OK")
+		case strings.HasPrefix(upper, "QUIT"):
+			write("221 bye")
+			return
+		default:
+			write("500 unrecognized command")
+		}
+	}
+}
+
+func TestWorkerProcessRecordsSentAndBounced(t *testing.T) {
+	server := startFakeSMTPServer(t, map[string]int{"bad@example.com": 550})
+	host, port, _ := net.SplitHostPort(server.addr())
+
+	store := newFakeStore(
+		&MailJob{ID: "job-1", Subject: "hi", Body: "hello", Status: StatusRunning},
+		[]*Recipient{{Email: "good@example.com"}, {Email: "bad@example.com"}},
+	)
+
+	w := &Worker{
+		Store:          store,
+		Mailer:         mail.NewMailer(host, port, "", "", "from@example.com"),
+		ListRecipients: storeRecipientLister(store),
+	}
+
+	job, err := store.GetJob(context.Background(), "job-1")
+	if err != nil {
+		t.Fatalf("GetJob: %s", err)
+	}
+	w.process(context.Background(), job)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	good := store.recipients["good@example.com"]
+	if good.Status != RecipientSent {
+		t.Errorf("good@example.com status = %s, want %s", good.Status, RecipientSent)
+	}
+	if good.Attempts != 1 {
+		t.Errorf("good@example.com attempts = %d, want 1", good.Attempts)
+	}
+
+	bad := store.recipients["bad@example.com"]
+	if bad.Status != RecipientBounced {
+		t.Errorf("bad@example.com status = %s, want %s", bad.Status, RecipientBounced)
+	}
+	if bad.LastError == "" {
+		t.Error("expected bounced recipient to carry the rejection error")
+	}
+
+	if store.job.Status != StatusCompleted {
+		t.Errorf("job status = %s, want %s", store.job.Status, StatusCompleted)
+	}
+}
+
+func TestWorkerProcessRecordsRetriesThenFailure(t *testing.T) {
+	server := startFakeSMTPServer(t, map[string]int{"flaky@example.com": 450})
+	host, port, _ := net.SplitHostPort(server.addr())
+
+	store := newFakeStore(
+		&MailJob{ID: "job-1", Subject: "hi", Body: "hello", Status: StatusRunning},
+		[]*Recipient{{Email: "flaky@example.com"}},
+	)
+
+	mailer := mail.NewMailer(host, port, "", "", "from@example.com")
+	mailer.MaxAttempts = 3
+	mailer.RetryBackoff = time.Millisecond
+	w := &Worker{Store: store, Mailer: mailer, ListRecipients: storeRecipientLister(store)}
+
+	job, _ := store.GetJob(context.Background(), "job-1")
+	w.process(context.Background(), job)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	r := store.recipients["flaky@example.com"]
+	// The fake server always answers 450 for this address, so every attempt
+	// fails transiently and the final recorded state is "failed" with all
+	// attempts counted, plus at least one intermediate "retrying" update
+	// (overwritten by the final status, but the attempts count proves the
+	// retries actually happened).
+	if r.Status != RecipientFailed {
+		t.Errorf("flaky@example.com status = %s, want %s", r.Status, RecipientFailed)
+	}
+	if r.Attempts != 3 {
+		t.Errorf("flaky@example.com attempts = %d, want 3", r.Attempts)
+	}
+}
+
+func TestWorkerProcessCancellationMarksUnattemptedRecipientsFailed(t *testing.T) {
+	store := newFakeStore(
+		&MailJob{ID: "job-1", Subject: "hi", Body: "hello", Status: StatusRunning},
+		[]*Recipient{{Email: "a@example.com"}},
+	)
+
+	w := &Worker{
+		Store:          store,
+		Mailer:         mail.NewMailer("127.0.0.1", "1", "", "", "from@example.com"),
+		ListRecipients: storeRecipientLister(store),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	job, _ := store.GetJob(ctx, "job-1")
+	w.process(ctx, job)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	r := store.recipients["a@example.com"]
+	if r.Status != RecipientFailed {
+		t.Errorf("a@example.com status = %s, want %s (recipient must not be left at queued)", r.Status, RecipientFailed)
+	}
+	if r.LastError == "" {
+		t.Error("expected a descriptive error even though the recipient was never attempted")
+	}
+}
@@ -20,43 +20,429 @@
 package mail
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"math/rand"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
 )
 
-type Mailer struct {
+// Message is a single outbound email addressed to one recipient. Set Body
+// for a single-part message (its content type is sniffed); set TextBody and
+// HTMLBody together for a multipart/alternative message carrying both.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+
+	TextBody string
+	HTMLBody string
+}
+
+// Result is the delivery outcome for one Message passed to SendMailContext.
+type Result struct {
+	To      string
+	Success bool
+	Error   error
+
+	// Attempts is how many times delivery was actually tried, so a caller
+	// tracking per-recipient state doesn't have to assume a fixed count.
+	Attempts int
+
+	// Permanent is set when Error is an SMTP 5xx response: the server
+	// rejected the message outright (e.g. no such mailbox), so retrying it
+	// would be pointless and it was not retried.
+	Permanent bool
+}
+
+// SMTPSettings is the subset of Mailer's configuration that can change while
+// the process is running (see config.Manager's hot-reloadable fields).
+// Mailer guards these behind a mutex since they're written from the config
+// watch goroutine and read from every in-flight send.
+type SMTPSettings struct {
 	Host     string
 	Port     string
 	Username string
 	Password string
-	From     string
+
+	// StartTLS upgrades a plaintext connection via the STARTTLS extension
+	// when the server advertises it. ImplicitTLS dials straight into TLS
+	// (e.g. port 465) and takes precedence over StartTLS when both are set.
+	StartTLS    bool
+	ImplicitTLS bool
+
+	// Concurrency is the number of SMTP connections SendMailContext keeps
+	// open at once. It defaults to 1 when unset.
+	Concurrency int
+}
+
+// Mailer delivers mail over SMTP, optionally authenticated and encrypted.
+type Mailer struct {
+	From string
+
+	// MaxAttempts is the number of times SendMailContext will try a given
+	// message, including the first attempt, before giving up. It defaults
+	// to 1 (no retry) when unset.
+	MaxAttempts int
+
+	// RetryBackoff is the base delay between attempts; it is multiplied by
+	// the attempt number to back off. It defaults to one second when unset.
+	RetryBackoff time.Duration
+
+	mu       sync.RWMutex
+	settings SMTPSettings
 }
 
 func NewMailer(host, port, username, password, from string) *Mailer {
-	return &Mailer{
-		Host:     host,
-		Port:     port,
-		Username: username,
-		Password: password,
-		From:     from,
-	}
-}
-
-func (m *Mailer) SendMail(to []string, subject, body string) error {
-	auth := smtp.PlainAuth("", m.Username, m.Password, m.Host)
-	
-	for _, recipient := range to {
-		msg := []byte(fmt.Sprintf("From: %s\r\n"+
-			"To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"\r\n"+
-			"%s\r\n", m.From, recipient, subject, body))
-
-		err := smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{recipient}, msg)
+	m := &Mailer{From: from}
+	m.settings = SMTPSettings{Host: host, Port: port, Username: username, Password: password}
+	return m
+}
+
+// Configure replaces m's SMTP connection settings. It's safe to call
+// concurrently with an in-flight SendMailContext, e.g. from a config file
+// watch callback while a campaign is sending: in-flight connections finish
+// with whatever settings they dialed with, and the next dial picks up the
+// new ones.
+func (m *Mailer) Configure(s SMTPSettings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings = s
+}
+
+func (m *Mailer) current() SMTPSettings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.settings
+}
+
+// SendMailContext delivers msgs concurrently across a pool of SMTP
+// connections, retrying each message with a backoff on failure. It returns
+// one Result per message, in the same order as msgs.
+func (m *Mailer) SendMailContext(ctx context.Context, msgs []Message) []Result {
+	return m.sendMailContext(ctx, msgs, nil)
+}
+
+// SendMailContextWithProgress behaves like SendMailContext, additionally
+// calling onAttempt after each attempt that failed but will be retried (not
+// the final one), passing the attempt number just completed. This lets a
+// caller that persists per-recipient delivery state (e.g. jobs.Worker)
+// record a message as retrying before its eventual outcome is known.
+func (m *Mailer) SendMailContextWithProgress(ctx context.Context, msgs []Message, onAttempt func(msg Message, attempt int, err error)) []Result {
+	return m.sendMailContext(ctx, msgs, onAttempt)
+}
+
+func (m *Mailer) sendMailContext(ctx context.Context, msgs []Message, onAttempt func(Message, int, error)) []Result {
+	results := make([]Result, len(msgs))
+
+	workers := m.current().Concurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(msgs) {
+		workers = len(msgs)
+	}
+	if workers == 0 {
+		return results
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var client *smtp.Client
+			defer func() {
+				if client != nil {
+					client.Close()
+				}
+			}()
+
+			for idx := range jobs {
+				results[idx] = m.deliverWithRetry(ctx, &client, msgs[idx], onAttempt)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range msgs {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	return results
+}
+
+// deliverWithRetry sends msg over *client, (re)dialing when client is nil or
+// has gone bad, retrying up to m.MaxAttempts times with a growing backoff. A
+// permanent SMTP rejection (a 5xx response) is never retried, since the
+// server has already said trying again won't help. onAttempt, if non-nil,
+// is called after each attempt that will be retried.
+func (m *Mailer) deliverWithRetry(ctx context.Context, client **smtp.Client, msg Message, onAttempt func(Message, int, error)) Result {
+	attempts := m.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if ctx.Err() != nil {
+			return Result{To: msg.To, Error: ctx.Err(), Attempts: attempt}
+		}
+
+		if *client == nil {
+			c, err := m.dial()
+			if err != nil {
+				lastErr = fmt.Errorf("dial: %w", err)
+				m.reportRetry(onAttempt, msg, attempt, attempts, lastErr)
+				m.sleepBackoff(ctx, attempt)
+				continue
+			}
+			*client = c
+		}
+
+		if err := m.sendOne(*client, msg); err != nil {
+			lastErr = err
+			(*client).Close()
+			*client = nil
+
+			if isPermanentSMTPError(err) {
+				return Result{To: msg.To, Error: lastErr, Attempts: attempt + 1, Permanent: true}
+			}
+
+			m.reportRetry(onAttempt, msg, attempt, attempts, lastErr)
+			m.sleepBackoff(ctx, attempt)
+			continue
+		}
+
+		return Result{To: msg.To, Success: true, Attempts: attempt + 1}
+	}
+
+	return Result{To: msg.To, Error: lastErr, Attempts: attempts}
+}
+
+func (m *Mailer) reportRetry(onAttempt func(Message, int, error), msg Message, attempt, attempts int, err error) {
+	if onAttempt != nil && attempt < attempts-1 {
+		onAttempt(msg, attempt+1, err)
+	}
+}
+
+// isPermanentSMTPError reports whether err is an SMTP reply in the 5xx
+// range, the class of response a server uses to permanently reject a
+// message (e.g. no such mailbox) rather than ask the sender to try again.
+func isPermanentSMTPError(err error) bool {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code >= 500 && protoErr.Code < 600
+	}
+	return false
+}
+
+func (m *Mailer) sleepBackoff(ctx context.Context, attempt int) {
+	backoff := m.RetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	select {
+	case <-time.After(backoff * time.Duration(attempt+1)):
+	case <-ctx.Done():
+	}
+}
+
+// dial opens a new, authenticated SMTP connection according to m's TLS
+// settings. Callers own the returned client and must Close it.
+func (m *Mailer) dial() (*smtp.Client, error) {
+	s := m.current()
+	addr := s.Host + ":" + s.Port
+
+	var client *smtp.Client
+	if s.ImplicitTLS {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: s.Host})
+		if err != nil {
+			return nil, err
+		}
+		client, err = smtp.NewClient(conn, s.Host)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	} else {
+		c, err := smtp.Dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		client = c
+
+		if s.StartTLS {
+			if ok, _ := client.Extension("STARTTLS"); ok {
+				if err := client.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+					client.Close()
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if s.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", s.Username, s.Password, s.Host)
+			if err := client.Auth(auth); err != nil {
+				client.Close()
+				return nil, err
+			}
+		}
+	}
+
+	return client, nil
+}
+
+// sendOne issues a MAIL/RCPT/DATA sequence for msg over an already
+// established client, leaving the connection open for the next message.
+func (m *Mailer) sendOne(client *smtp.Client, msg Message) error {
+	if err := client.Mail(m.From); err != nil {
+		return fmt.Errorf("MAIL FROM: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("RCPT TO: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA: %w", err)
+	}
+	rendered, err := buildMessage(m.From, msg)
+	if err != nil {
+		w.Close()
+		return fmt.Errorf("build message: %w", err)
+	}
+	if _, err := w.Write(rendered); err != nil {
+		w.Close()
+		return fmt.Errorf("write body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("close body: %w", err)
+	}
+
+	// Reset the envelope so the connection is ready for the next message.
+	return client.Reset()
+}
+
+// buildMessage renders msg into a MIME-encoded message. When both TextBody
+// and HTMLBody are set it builds a multipart/alternative message; otherwise
+// it quoted-printable encodes Body as a single part, sniffing text/html vs
+// text/plain from its content.
+func buildMessage(from string, msg Message) ([]byte, error) {
+	var headers bytes.Buffer
+	fmt.Fprintf(&headers, "From: %s\r\n", from)
+	fmt.Fprintf(&headers, "To: %s\r\n", stripHeaderControlChars(msg.To))
+	fmt.Fprintf(&headers, "Subject: %s\r\n", stripHeaderControlChars(msg.Subject))
+	fmt.Fprintf(&headers, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&headers, "Message-ID: <%s>\r\n", newMessageID(from))
+	headers.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.TextBody != "" && msg.HTMLBody != "" {
+		body, boundary, err := buildAlternativeBody(msg.TextBody, msg.HTMLBody)
 		if err != nil {
-			return fmt.Errorf("failed to send email to %s: %v", recipient, err)
+			return nil, err
 		}
+		fmt.Fprintf(&headers, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+		return append(headers.Bytes(), body...), nil
+	}
+
+	plainBody := msg.Body
+	if plainBody == "" {
+		plainBody = msg.TextBody
+	}
+	contentType := "text/plain; charset=UTF-8"
+	if looksLikeHTML(plainBody) {
+		contentType = "text/html; charset=UTF-8"
+	}
+	fmt.Fprintf(&headers, "Content-Type: %s\r\n", contentType)
+	headers.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&headers)
+	qp.Write([]byte(plainBody))
+	qp.Close()
+
+	return headers.Bytes(), nil
+}
+
+// buildAlternativeBody renders the multipart/alternative part of a
+// message, returning its bytes and the boundary used so the caller can
+// declare it in the Content-Type header.
+func buildAlternativeBody(text, html string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	if err := writeQuotedPrintablePart(mw, "text/plain; charset=UTF-8", text); err != nil {
+		return nil, "", err
+	}
+	if err := writeQuotedPrintablePart(mw, "text/html; charset=UTF-8", html); err != nil {
+		return nil, "", err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	return buf.Bytes(), mw.Boundary(), nil
+}
+
+func writeQuotedPrintablePart(mw *multipart.Writer, contentType, body string) error {
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("create part: %w", err)
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("write part body: %w", err)
+	}
+	return qp.Close()
+}
+
+// stripHeaderControlChars removes CR and LF from a value bound for a raw
+// RFC822 header line. Subject and To both ultimately derive from
+// template-rendered, recipient-controlled text (e.g. a GitHub display
+// name), and without this a crafted "\r\n" injects arbitrary extra headers
+// into the message.
+func stripHeaderControlChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func looksLikeHTML(body string) bool {
+	trimmed := strings.TrimSpace(body)
+	return strings.HasPrefix(trimmed, "<") && strings.HasSuffix(trimmed, ">")
+}
+
+func newMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at != -1 {
+		domain = from[at+1:]
 	}
-	
-	return nil
+	return fmt.Sprintf("%d.%d@%s", time.Now().UnixNano(), rand.Int63(), domain)
 }
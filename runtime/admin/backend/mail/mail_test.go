@@ -0,0 +1,108 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mail
+
+import (
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func TestLooksLikeHTML(t *testing.T) {
+	cases := []struct {
+		body string
+		want bool
+	}{
+		{"<p>hi</p>", true},
+		{"  <html>hi</html>  ", true},
+		{"plain text", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := looksLikeHTML(c.body); got != c.want {
+			t.Errorf("looksLikeHTML(%q) = %v, want %v", c.body, got, c.want)
+		}
+	}
+}
+
+func TestBuildMessageSinglePart(t *testing.T) {
+	rendered, err := buildMessage("sender@example.com", Message{To: "rcpt@example.com", Subject: "hi", Body: "hello"})
+	if err != nil {
+		t.Fatalf("buildMessage: %s", err)
+	}
+	out := string(rendered)
+	if !strings.Contains(out, "Content-Type: text/plain; charset=UTF-8") {
+		t.Errorf("expected plain text content type, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Subject: hi") {
+		t.Errorf("expected subject header, got:\n%s", out)
+	}
+}
+
+func TestBuildMessageStripsCRLFFromSubjectAndTo(t *testing.T) {
+	rendered, err := buildMessage("sender@example.com", Message{
+		To:      "rcpt@example.com\r\nBcc: evil@example.com",
+		Subject: "hi\r\nX-Injected: true",
+		Body:    "hello",
+	})
+	if err != nil {
+		t.Fatalf("buildMessage: %s", err)
+	}
+	for _, line := range strings.Split(string(rendered), "\r\n") {
+		if strings.HasPrefix(line, "Bcc:") || strings.HasPrefix(line, "X-Injected:") {
+			t.Errorf("expected CR/LF in To/Subject to be stripped, got an injected header line: %q", line)
+		}
+	}
+}
+
+func TestBuildMessageAlternative(t *testing.T) {
+	rendered, err := buildMessage("sender@example.com", Message{To: "rcpt@example.com", Subject: "hi", TextBody: "hello", HTMLBody: "<p>hello</p>"})
+	if err != nil {
+		t.Fatalf("buildMessage: %s", err)
+	}
+	out := string(rendered)
+	if !strings.Contains(out, "multipart/alternative") {
+		t.Errorf("expected multipart/alternative content type, got:\n%s", out)
+	}
+	if !strings.Contains(out, "text/plain") || !strings.Contains(out, "text/html") {
+		t.Errorf("expected both text/plain and text/html parts, got:\n%s", out)
+	}
+}
+
+func TestIsPermanentSMTPError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"5xx is permanent", &textproto.Error{Code: 550, Msg: "no such mailbox"}, true},
+		{"4xx is transient", &textproto.Error{Code: 450, Msg: "mailbox busy"}, false},
+		{"non-SMTP error is transient", errDial{}, false},
+	}
+	for _, c := range cases {
+		if got := isPermanentSMTPError(c.err); got != c.want {
+			t.Errorf("%s: isPermanentSMTPError() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+type errDial struct{}
+
+func (errDial) Error() string { return "dial failed" }
@@ -21,71 +21,214 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
 	"github.com/google/go-github/v72/github"
-	"golang.org/x/oauth2"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/auth"
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/config"
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/githubclient"
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/jobs"
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/mail"
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/templates"
 )
 
-type Config struct {
-	GithubToken string
-	OrgName     string
-	SmtpHost    string
-	SmtpPort    string
-	SmtpUser    string
-	SmtpPass    string
-	FromEmail   string
-}
-
-var config Config
+// appCfg is a snapshot taken at startup of the fields that only take effect
+// on the next process start (OIDC settings, session secret, database DSN,
+// GitHub auth mode, ...). Fields that hot-reload (SMTP creds, org name,
+// CORS origins, mail concurrency) are read from cfgManager.Current()
+// instead, wherever they're used, so a config file change is picked up
+// without a restart.
+var appCfg config.Config
+var cfgManager *config.Manager
+var mailer *mail.Mailer
+var authProvider *auth.Provider
+var ghClient *githubclient.Client
+var jobStore jobs.Store
+var jobWorker *jobs.Worker
+var templateStore templates.Store
 
 func setupRouter() *gin.Engine {
 	r := gin.Default()
 
-	// Configure CORS
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
-		AllowCredentials: true,
-	}))
+	r.Use(corsMiddleware())
 
 	// Health check endpoints
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	// Routes
-	r.GET("/api/members", getOrgMembers)
-	r.POST("/api/send-mail", sendMailToMembers)
+	// Auth routes
+	r.GET("/auth/login", authLoginHandler)
+	r.GET("/auth/callback", authCallbackHandler)
+	r.POST("/auth/logout", authLogoutHandler)
+	r.GET("/auth/userinfo", auth.RequireSession(authProvider), authUserinfoHandler)
+
+	// Routes - any authenticated member may list members, but only members
+	// of the configured admin group may send mail or manage campaigns.
+	r.GET("/api/members", auth.RequireSession(authProvider), getOrgMembers)
+	r.POST("/api/send-mail", auth.RequireSession(authProvider), auth.RequireGroup(authProvider.AdminGroup()), enqueueMailJob)
+
+	r.GET("/api/jobs", auth.RequireSession(authProvider), listMailJobs)
+	r.GET("/api/jobs/:id", auth.RequireSession(authProvider), getMailJob)
+	r.GET("/api/jobs/:id/recipients", auth.RequireSession(authProvider), listMailJobRecipients)
+	r.POST("/api/jobs/:id/cancel", auth.RequireSession(authProvider), auth.RequireGroup(authProvider.AdminGroup()), cancelMailJob)
+
+	r.GET("/api/templates", auth.RequireSession(authProvider), listMailTemplates)
+	r.POST("/api/templates", auth.RequireSession(authProvider), auth.RequireGroup(authProvider.AdminGroup()), createMailTemplate)
+	r.GET("/api/templates/:id", auth.RequireSession(authProvider), getMailTemplate)
+	r.PUT("/api/templates/:id", auth.RequireSession(authProvider), auth.RequireGroup(authProvider.AdminGroup()), updateMailTemplate)
+	r.DELETE("/api/templates/:id", auth.RequireSession(authProvider), auth.RequireGroup(authProvider.AdminGroup()), deleteMailTemplate)
+	r.POST("/api/templates/:id/preview", auth.RequireSession(authProvider), previewMailTemplate)
 
 	return r
 }
 
+// corsMiddleware enforces the allow-list from cfgManager on every request,
+// re-read live so an updated cors_allow_origins takes effect without a
+// restart. There's deliberately no wildcard fallback: "*" combined with
+// credentialed requests (which this API requires, for the session cookie)
+// is rejected by browsers anyway, so an explicit, configured allow-list is
+// the only option that actually works.
+func corsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && isAllowedOrigin(origin, cfgManager.Current().CORSAllowOrigins) {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Access-Control-Allow-Credentials", "true")
+			c.Header("Vary", "Origin")
+		}
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Idempotency-Key")
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+func isAllowedOrigin(origin string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == origin {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
-	// Load configuration
-	config = Config{
-		GithubToken: os.Getenv("GITHUB_TOKEN"),
-		OrgName:     os.Getenv("GITHUB_ORG"),
-		SmtpHost:    os.Getenv("SMTP_HOST"),
-		SmtpPort:    os.Getenv("SMTP_PORT"),
-		SmtpUser:    os.Getenv("SMTP_USER"),
-		SmtpPass:    os.Getenv("SMTP_PASS"),
-		FromEmail:   os.Getenv("FROM_EMAIL"),
+	var err error
+	cfgManager, err = config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		log.Fatalf("config: %s\n", err)
+	}
+	appCfg = cfgManager.Current()
+	log.Printf("config loaded: %+v\n", appCfg.Redacted())
+
+	privateKeyPEM, err := githubAppPrivateKeyPEM(appCfg)
+	if err != nil {
+		log.Fatalf("github app private key: %s\n", err)
 	}
 
+	mailer = mail.NewMailer(appCfg.SmtpHost, appCfg.SmtpPort, appCfg.SmtpUser, appCfg.SmtpPass, appCfg.FromEmail)
+	mailer.Configure(mail.SMTPSettings{
+		Host:        appCfg.SmtpHost,
+		Port:        appCfg.SmtpPort,
+		Username:    appCfg.SmtpUser,
+		Password:    appCfg.SmtpPass,
+		StartTLS:    appCfg.SmtpStartTLS,
+		ImplicitTLS: appCfg.SmtpImplicitTLS,
+		Concurrency: appCfg.MailConcurrency,
+	})
+	mailer.MaxAttempts = appCfg.MailMaxAttempts
+	mailer.RetryBackoff = appCfg.MailRetryBackoff()
+
+	// Apply the SMTP/concurrency fields the config package promises to
+	// hot-reload onto the shared *mail.Mailer, so an updated config file
+	// takes effect on the next send without a restart. Configure takes its
+	// own lock, so this is safe to call concurrently with an in-flight send.
+	cfgManager.Watch(func(next config.Config) {
+		mailer.Configure(mail.SMTPSettings{
+			Host:        next.SmtpHost,
+			Port:        next.SmtpPort,
+			Username:    next.SmtpUser,
+			Password:    next.SmtpPass,
+			StartTLS:    next.SmtpStartTLS,
+			ImplicitTLS: next.SmtpImplicitTLS,
+			Concurrency: next.MailConcurrency,
+		})
+		log.Println("config: reloaded")
+	})
+
 	// Create context that listens for the interrupt signal from the OS
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	authProvider, err = auth.NewProvider(ctx, auth.Config{
+		IssuerURL:     appCfg.OidcIssuerURL,
+		ClientID:      appCfg.OidcClientID,
+		ClientSecret:  appCfg.OidcClientSecret,
+		RedirectURL:   appCfg.OidcRedirectURL,
+		AdminGroup:    appCfg.OidcAdminGroup,
+		SessionSecret: appCfg.SessionSecret,
+		SessionTTL:    appCfg.SessionTTL(),
+	})
+	if err != nil {
+		log.Fatalf("oidc provider: %s\n", err)
+	}
+
+	ghClient, err = githubclient.NewClient(ctx, githubclient.Config{
+		Mode:           githubclient.AuthMode(appCfg.GithubAuthMode),
+		Token:          appCfg.GithubToken,
+		AppID:          appCfg.GithubAppID,
+		InstallationID: appCfg.GithubAppInstallationID,
+		PrivateKeyPEM:  privateKeyPEM,
+	})
+	if err != nil {
+		log.Fatalf("github client: %s\n", err)
+	}
+
+	db, err := sql.Open(appCfg.DBDriver, appCfg.DBDSN)
+	if err != nil {
+		log.Fatalf("open database: %s\n", err)
+	}
+	defer db.Close()
+
+	jobStore, err = jobs.NewSQLStore(ctx, db, appCfg.DBDriver)
+	if err != nil {
+		log.Fatalf("jobs store: %s\n", err)
+	}
+
+	templateStore, err = templates.NewSQLStore(ctx, db, appCfg.DBDriver)
+	if err != nil {
+		log.Fatalf("templates store: %s\n", err)
+	}
+
+	jobWorker = &jobs.Worker{
+		Store:          jobStore,
+		Mailer:         mailer,
+		ListRecipients: listJobRecipients,
+		Render:         renderJobMessage,
+		PollInterval:   appCfg.JobPollInterval(),
+	}
+	go jobWorker.Run(ctx)
+
 	r := setupRouter()
 
 	srv := &http.Server{
@@ -119,59 +262,510 @@ func main() {
 
 	log.Println("Server exiting")
 }
-		AllowHeaders:     []string{"Origin", "Content-Type"},
-		AllowCredentials: true,
-	}))
 
-	// Routes
-	r.GET("/api/members", getOrgMembers)
-	r.POST("/api/send-mail", sendMailToMembers)
-
-	log.Fatal(r.Run(":3000"))
+// githubAppPrivateKeyPEM resolves the GitHub App private key configured by
+// cfg, preferring a path to the PEM file (so the key itself never has to be
+// stored in the config file or an environment variable) and falling back
+// to the raw PEM contents for deployments that can only set config values.
+// It's a no-op for AuthModePAT, which doesn't use a private key.
+func githubAppPrivateKeyPEM(cfg config.Config) ([]byte, error) {
+	if githubclient.AuthMode(cfg.GithubAuthMode) != githubclient.AuthModeApp {
+		return nil, nil
+	}
+	if cfg.GithubAppPrivateKeyPath != "" {
+		data, err := os.ReadFile(cfg.GithubAppPrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", cfg.GithubAppPrivateKeyPath, err)
+		}
+		return data, nil
+	}
+	return []byte(cfg.GithubAppPrivateKey), nil
 }
 
 func getOrgMembers(c *gin.Context) {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: config.GithubToken},
-	)
-	tc := oauth2.NewClient(c, ts)
-	client := github.NewClient(tc)
+	members, err := ghClient.ListMembers(c, cfgManager.Current().OrgName)
+	if err != nil {
+		respondGithubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// resolveMember fetches a member's full profile and resolves the address
+// they should receive mail at: their public email if they have one set,
+// otherwise their GitHub noreply alias. It returns ok=false only when
+// neither an email nor the fields needed to build a noreply alias exist.
+func resolveMember(ctx context.Context, member *github.User) (email, name string, ok bool) {
+	profile, err := ghClient.GetUser(ctx, member.GetLogin())
+	if err != nil {
+		profile = member
+	}
+
+	email = profile.GetEmail()
+	if email == "" && profile.GetID() != 0 && profile.GetLogin() != "" {
+		email = fmt.Sprintf("%d+%s@users.noreply.github.com", profile.GetID(), profile.GetLogin())
+	}
+	if email == "" {
+		return "", "", false
+	}
+
+	return email, profile.GetName(), true
+}
+
+// listJobRecipients resolves a MailJob's recipients by listing the org's
+// members from GitHub, narrowing to job.RecipientFilter when set, and
+// resolving each remaining member's email.
+func listJobRecipients(ctx context.Context, job *jobs.MailJob) ([]*jobs.Recipient, error) {
+	members, err := ghClient.ListMembers(ctx, cfgManager.Current().OrgName)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedLogins := parseRecipientFilter(job.RecipientFilter)
+
+	recipients := make([]*jobs.Recipient, 0, len(members))
+	for _, member := range members {
+		if allowedLogins != nil && !allowedLogins[strings.ToLower(member.GetLogin())] {
+			continue
+		}
+		email, name, ok := resolveMember(ctx, member)
+		if !ok {
+			continue
+		}
+		recipients = append(recipients, &jobs.Recipient{
+			Email:  email,
+			Login:  member.GetLogin(),
+			Name:   name,
+			Status: jobs.RecipientQueued,
+		})
+	}
+
+	return recipients, nil
+}
+
+// parseRecipientFilter parses a MailJob's RecipientFilter, a comma-separated
+// list of GitHub logins, into a case-insensitive allow-list. It returns nil
+// (no filtering) for an empty or whitespace-only filter.
+func parseRecipientFilter(raw string) map[string]bool {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	logins := make(map[string]bool)
+	for _, login := range strings.Split(raw, ",") {
+		login = strings.ToLower(strings.TrimSpace(login))
+		if login != "" {
+			logins[login] = true
+		}
+	}
+	if len(logins) == 0 {
+		return nil
+	}
+	return logins
+}
+
+// sampleMember returns a representative GitHub profile to render a
+// template preview against. Under PAT auth this is the token's own user;
+// a GitHub App installation token isn't a user token, so it falls back to
+// the org's first member instead.
+func sampleMember(ctx context.Context) (*github.User, error) {
+	orgName := cfgManager.Current().OrgName
+	if appCfg.GithubAuthMode == string(githubclient.AuthModePAT) {
+		if self, err := ghClient.GetUser(ctx, ""); err == nil {
+			return self, nil
+		}
+	}
+
+	members, err := ghClient.ListMembers(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("org %s has no members to preview against", orgName)
+	}
+	return members[0], nil
+}
+
+// respondGithubError maps a githubclient error to an HTTP response,
+// surfacing a 429 with Retry-After when the GitHub API's own rate limit
+// is the cause instead of a generic 500.
+func respondGithubError(c *gin.Context, err error) {
+	var rlErr *githubclient.RateLimitError
+	if errors.As(err, &rlErr) {
+		c.Header("Retry-After", strconv.Itoa(int(rlErr.RetryAfter.Seconds())))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// renderJobMessage executes job's template for recipient, merging the
+// job's sender-supplied variables with the recipient's own GitHub profile
+// fields (which take precedence, so a template can't be tricked into
+// impersonating a different member).
+func renderJobMessage(ctx context.Context, job *jobs.MailJob, recipient *jobs.Recipient) (subject, text, html string, err error) {
+	tmpl, err := templateStore.GetTemplate(ctx, job.TemplateID)
+	if err != nil {
+		return "", "", "", fmt.Errorf("load template %s: %w", job.TemplateID, err)
+	}
+
+	vars := make(map[string]string, len(job.TemplateVars)+3)
+	for k, v := range job.TemplateVars {
+		vars[k] = v
+	}
+	for k, v := range templates.StandardVars(recipient.Login, recipient.Name, cfgManager.Current().OrgName) {
+		vars[k] = v
+	}
 
-	members, _, err := client.Organizations.ListMembers(c, config.OrgName, nil)
+	rendered, err := templates.Render(tmpl, vars)
+	if err != nil {
+		return "", "", "", err
+	}
+	return rendered.Subject, rendered.Text, rendered.HTML, nil
+}
+
+type createJobRequest struct {
+	TemplateID string            `json:"template_id" binding:"required"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	// RecipientFilter, if set, is a comma-separated list of GitHub logins to
+	// restrict the campaign to; see parseRecipientFilter. Left empty, the
+	// campaign mails every org member.
+	RecipientFilter string     `json:"recipient_filter,omitempty"`
+	ScheduledAt     *time.Time `json:"scheduled_at,omitempty"`
+}
+
+// enqueueMailJob records a MailJob against a template and returns
+// immediately; the background Worker resolves recipients, renders the
+// template per recipient, and sends once it leases the job. An
+// Idempotency-Key header lets the frontend retry a submission without
+// creating a duplicate campaign.
+func enqueueMailJob(c *gin.Context) {
+	var req createJobRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	idempotencyKey := c.GetHeader("Idempotency-Key")
+	if idempotencyKey != "" {
+		existing, err := jobStore.JobByIdempotencyKey(c, idempotencyKey)
+		if err != nil && !errors.Is(err, jobs.ErrNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err == nil {
+			c.JSON(http.StatusAccepted, gin.H{"job_id": existing.ID, "status": existing.Status})
+			return
+		}
+	}
+
+	tmpl, err := templateStore.GetTemplate(c, req.TemplateID)
+	if errors.Is(err, templates.ErrNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "template not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, members)
+	job := &jobs.MailJob{
+		IdempotencyKey:  idempotencyKey,
+		TemplateID:      tmpl.ID,
+		Subject:         tmpl.Subject,
+		TemplateVars:    req.Variables,
+		RecipientFilter: req.RecipientFilter,
+		ScheduledAt:     req.ScheduledAt,
+	}
+
+	if err := jobStore.CreateJob(c, job); err != nil {
+		// A concurrent retry of the same submission can race past the
+		// JobByIdempotencyKey check above; CreateJob's uniqueness error
+		// catches that at the database level, so fall back to looking the
+		// winning job up instead of failing the request.
+		if errors.Is(err, jobs.ErrDuplicateIdempotencyKey) {
+			existing, lookupErr := jobStore.JobByIdempotencyKey(c, idempotencyKey)
+			if lookupErr == nil {
+				c.JSON(http.StatusAccepted, gin.H{"job_id": existing.ID, "status": existing.Status})
+				return
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"job_id": job.ID, "status": job.Status})
 }
 
-func sendMailToMembers(c *gin.Context) {
-	var mailRequest struct {
-		Subject string `json:"subject"`
-		Body    string `json:"body"`
+func listMailJobs(c *gin.Context) {
+	jobList, err := jobStore.ListJobs(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{"jobs": jobList})
+}
 
-	if err := c.BindJSON(&mailRequest); err != nil {
+func getMailJob(c *gin.Context) {
+	job, err := jobStore.GetJob(c, c.Param("id"))
+	if errors.Is(err, jobs.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+func listMailJobRecipients(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := jobStore.GetJob(c, id); err != nil {
+		if errors.Is(err, jobs.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipients, err := jobStore.ListRecipients(c, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recipients": recipients})
+}
+
+func cancelMailJob(c *gin.Context) {
+	id := c.Param("id")
+
+	job, err := jobStore.GetJob(c, id)
+	if errors.Is(err, jobs.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch job.Status {
+	case jobs.StatusCompleted, jobs.StatusCancelled, jobs.StatusFailed:
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("job is already %s", job.Status)})
+		return
+	}
+
+	if err := jobStore.UpdateJobStatus(c, id, jobs.StatusCancelled); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "job cancelled"})
+}
+
+const (
+	oidcStateCookie  = "oidc_state"
+	oidcNonceCookie  = "oidc_nonce"
+	oidcCookieMaxAge = 300 // long enough to complete the redirect round trip
+)
+
+// authLoginHandler starts the authorization-code flow, stashing the state
+// and nonce it binds to this attempt in short-lived cookies so callback can
+// verify them.
+func authLoginHandler(c *gin.Context) {
+	state, err := auth.RandomString(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	nonce, err := auth.RandomString(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.SetCookie(oidcStateCookie, state, oidcCookieMaxAge, "/", "", appCfg.SessionCookieSecure, true)
+	c.SetCookie(oidcNonceCookie, nonce, oidcCookieMaxAge, "/", "", appCfg.SessionCookieSecure, true)
+
+	c.Redirect(http.StatusFound, authProvider.AuthCodeURL(state, nonce))
+}
+
+// authCallbackHandler completes the authorization-code flow: it checks the
+// returned state against the login attempt's cookie, exchanges the code,
+// verifies the ID token, and issues a session cookie.
+func authCallbackHandler(c *gin.Context) {
+	wantState, err := c.Cookie(oidcStateCookie)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing state cookie"})
+		return
+	}
+	nonce, err := c.Cookie(oidcNonceCookie)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing nonce cookie"})
+		return
+	}
+	if c.Query("state") != wantState {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "state mismatch"})
+		return
+	}
+
+	claims, err := authProvider.Exchange(c, c.Query("code"), nonce)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessionCookie, err := authProvider.NewSessionCookie(claims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.SetCookie(oidcStateCookie, "", -1, "/", "", appCfg.SessionCookieSecure, true)
+	c.SetCookie(oidcNonceCookie, "", -1, "/", "", appCfg.SessionCookieSecure, true)
+	c.SetCookie(auth.SessionCookieName, sessionCookie, int(appCfg.SessionTTL().Seconds()), "/", "", appCfg.SessionCookieSecure, true)
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged in"})
+}
+
+func authLogoutHandler(c *gin.Context) {
+	c.SetCookie(auth.SessionCookieName, "", -1, "/", "", appCfg.SessionCookieSecure, true)
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+func authUserinfoHandler(c *gin.Context) {
+	claims, ok := auth.ClaimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+	c.JSON(http.StatusOK, claims)
+}
+
+func listMailTemplates(c *gin.Context) {
+	list, err := templateStore.ListTemplates(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"templates": list})
+}
+
+type templateRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Subject string `json:"subject" binding:"required"`
+	Body    string `json:"body" binding:"required"`
+}
+
+func createMailTemplate(c *gin.Context) {
+	var req templateRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl := &templates.Template{Name: req.Name, Subject: req.Subject, Body: req.Body}
+	if err := templateStore.CreateTemplate(c, tmpl); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tmpl)
+}
+
+func getMailTemplate(c *gin.Context) {
+	tmpl, err := templateStore.GetTemplate(c, c.Param("id"))
+	if errors.Is(err, templates.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tmpl)
+}
+
+func updateMailTemplate(c *gin.Context) {
+	var req templateRequest
+	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Get organization members
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: config.GithubToken},
-	)
-	tc := oauth2.NewClient(c, ts)
-	client := github.NewClient(tc)
+	tmpl := &templates.Template{ID: c.Param("id"), Name: req.Name, Subject: req.Subject, Body: req.Body}
+	if err := templateStore.UpdateTemplate(c, tmpl); err != nil {
+		if errors.Is(err, templates.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tmpl)
+}
+
+func deleteMailTemplate(c *gin.Context) {
+	if err := templateStore.DeleteTemplate(c, c.Param("id")); err != nil {
+		if errors.Is(err, templates.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "template deleted"})
+}
+
+type previewTemplateRequest struct {
+	Variables map[string]string `json:"variables,omitempty"`
+}
 
-	_, _, err := client.Organizations.ListMembers(c, config.OrgName, nil)
+// previewMailTemplate renders a template against a sample GitHub profile
+// (see sampleMember) so an admin can see what a recipient would receive
+// without sending or enqueueing anything.
+func previewMailTemplate(c *gin.Context) {
+	tmpl, err := templateStore.GetTemplate(c, c.Param("id"))
+	if errors.Is(err, templates.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Send emails (implement email sending logic)
-	// TODO: Implement actual email sending logic
+	var req previewTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	self, err := sampleMember(c)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": fmt.Sprintf("fetch sample profile: %s", err)})
+		return
+	}
+
+	vars := make(map[string]string, len(req.Variables)+3)
+	for k, v := range req.Variables {
+		vars[k] = v
+	}
+	for k, v := range templates.StandardVars(self.GetLogin(), self.GetName(), cfgManager.Current().OrgName) {
+		vars[k] = v
+	}
+
+	rendered, err := templates.Render(tmpl, vars)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Emails sent successfully"})
+	c.JSON(http.StatusOK, rendered)
 }
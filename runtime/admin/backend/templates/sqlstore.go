@@ -0,0 +1,160 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package templates
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/MioYuuIH/aup-learning-cloud/runtime/admin/backend/internal/dbutil"
+)
+
+// SQLStore is a Store backed by database/sql, sharing the schema
+// conventions of jobs.SQLStore so both can live in the same database.
+// Queries are written with "?" placeholders and rebound for driverName
+// before running, since lib/pq only accepts its own "$1, $2, ..." markers
+// (see dbutil.Rebind).
+type SQLStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLStore wraps db and ensures the templates schema exists. driverName
+// is the name db was opened with (e.g. "sqlite3" or "postgres").
+func NewSQLStore(ctx context.Context, db *sql.DB, driverName string) (*SQLStore, error) {
+	s := &SQLStore{db: db, driver: driverName}
+	if err := s.migrate(ctx); err != nil {
+		return nil, fmt.Errorf("migrate templates schema: %w", err)
+	}
+	return s, nil
+}
+
+func (s *SQLStore) rebind(query string) string {
+	return dbutil.Rebind(s.driver, query)
+}
+
+func (s *SQLStore) migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS mail_templates (
+			id         TEXT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			subject    TEXT NOT NULL,
+			body       TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)`)
+	return err
+}
+
+func (s *SQLStore) CreateTemplate(ctx context.Context, tmpl *Template) error {
+	id, err := dbutil.NewID()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	_, err = s.db.ExecContext(ctx, s.rebind(`
+		INSERT INTO mail_templates (id, name, subject, body, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		id, tmpl.Name, tmpl.Subject, tmpl.Body, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("insert template: %w", err)
+	}
+
+	tmpl.ID = id
+	tmpl.CreatedAt = now
+	tmpl.UpdatedAt = now
+	return nil
+}
+
+func (s *SQLStore) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	row := s.db.QueryRowContext(ctx, s.rebind(`
+		SELECT id, name, subject, body, created_at, updated_at FROM mail_templates WHERE id = ?`), id)
+	return scanTemplate(row)
+}
+
+func (s *SQLStore) ListTemplates(ctx context.Context) ([]*Template, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, subject, body, created_at, updated_at FROM mail_templates ORDER BY name ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*Template
+	for rows.Next() {
+		tmpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tmpl)
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLStore) UpdateTemplate(ctx context.Context, tmpl *Template) error {
+	now := time.Now()
+	res, err := s.db.ExecContext(ctx, s.rebind(`
+		UPDATE mail_templates SET name = ?, subject = ?, body = ?, updated_at = ? WHERE id = ?`),
+		tmpl.Name, tmpl.Subject, tmpl.Body, now, tmpl.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update template: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	tmpl.UpdatedAt = now
+	return nil
+}
+
+func (s *SQLStore) DeleteTemplate(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, s.rebind(`DELETE FROM mail_templates WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanTemplate(row dbutil.RowScanner) (*Template, error) {
+	tmpl := &Template{}
+	err := row.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Subject, &tmpl.Body, &tmpl.CreatedAt, &tmpl.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package templates
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestStore(t *testing.T) *SQLStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite3: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLStore(context.Background(), db, "sqlite3")
+	if err != nil {
+		t.Fatalf("NewSQLStore: %s", err)
+	}
+	return store
+}
+
+func TestCreateGetUpdateDeleteTemplate(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	tmpl := &Template{Name: "welcome", Subject: "Hi {{.Name}}", Body: "body"}
+	if err := store.CreateTemplate(ctx, tmpl); err != nil {
+		t.Fatalf("CreateTemplate: %s", err)
+	}
+	if tmpl.ID == "" {
+		t.Fatal("expected CreateTemplate to assign an ID")
+	}
+
+	got, err := store.GetTemplate(ctx, tmpl.ID)
+	if err != nil {
+		t.Fatalf("GetTemplate: %s", err)
+	}
+	if got.Name != tmpl.Name || got.Subject != tmpl.Subject {
+		t.Errorf("GetTemplate() = %+v, want matching %+v", got, tmpl)
+	}
+
+	tmpl.Subject = "Hello {{.Name}}"
+	if err := store.UpdateTemplate(ctx, tmpl); err != nil {
+		t.Fatalf("UpdateTemplate: %s", err)
+	}
+	got, err = store.GetTemplate(ctx, tmpl.ID)
+	if err != nil {
+		t.Fatalf("GetTemplate: %s", err)
+	}
+	if got.Subject != "Hello {{.Name}}" {
+		t.Errorf("Subject after update = %q, want %q", got.Subject, "Hello {{.Name}}")
+	}
+
+	if err := store.DeleteTemplate(ctx, tmpl.ID); err != nil {
+		t.Fatalf("DeleteTemplate: %s", err)
+	}
+	if _, err := store.GetTemplate(ctx, tmpl.ID); err != ErrNotFound {
+		t.Errorf("GetTemplate() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUpdateTemplateNotFound(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.UpdateTemplate(context.Background(), &Template{ID: "does-not-exist"}); err != ErrNotFound {
+		t.Errorf("UpdateTemplate() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListTemplatesOrdersByName(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	for _, name := range []string{"zeta", "alpha", "mu"} {
+		if err := store.CreateTemplate(ctx, &Template{Name: name, Subject: "s", Body: "b"}); err != nil {
+			t.Fatalf("CreateTemplate(%s): %s", name, err)
+		}
+	}
+
+	list, err := store.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("ListTemplates: %s", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("ListTemplates() returned %d templates, want 3", len(list))
+	}
+	want := []string{"alpha", "mu", "zeta"}
+	for i, tmpl := range list {
+		if tmpl.Name != want[i] {
+			t.Errorf("ListTemplates()[%d].Name = %q, want %q", i, tmpl.Name, want[i])
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package templates stores named mail templates and renders them per
+// recipient. A template's body is Markdown with Go text/template
+// placeholders (e.g. {{.Login}}); rendering substitutes variables first,
+// then converts the result to HTML, producing matching text/plain and
+// text/html alternatives from one source.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/yuin/goldmark"
+)
+
+// Template is a named, reusable mail body.
+type Template struct {
+	ID        string
+	Name      string
+	Subject   string // text/template source
+	Body      string // Markdown source with text/template placeholders
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Rendered is the result of executing a Template against a set of
+// variables for one recipient.
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// StandardVars returns the variables every template render gets for free,
+// in addition to whatever custom fields the sender supplied.
+func StandardVars(login, name, orgName string) map[string]string {
+	return map[string]string{
+		"Login":   login,
+		"Name":    name,
+		"OrgName": orgName,
+	}
+}
+
+// Render executes tmpl's subject and body against vars, then converts the
+// rendered Markdown body to HTML. vars is typically built with
+// StandardVars and then merged with the sender's custom fields.
+func Render(tmpl *Template, vars map[string]string) (*Rendered, error) {
+	subject, err := execute("subject", tmpl.Subject, vars)
+	if err != nil {
+		return nil, fmt.Errorf("render subject: %w", err)
+	}
+
+	text, err := execute("body", tmpl.Body, vars)
+	if err != nil {
+		return nil, fmt.Errorf("render body: %w", err)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := goldmark.Convert([]byte(text), &htmlBuf); err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+
+	return &Rendered{Subject: subject, Text: text, HTML: htmlBuf.String()}, nil
+}
+
+func execute(name, src string, vars map[string]string) (string, error) {
+	t, err := template.New(name).Parse(src)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025 Advanced Micro Devices, Inc. All rights reserved.
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSubstitutesVarsAndRendersMarkdown(t *testing.T) {
+	tmpl := &Template{
+		Subject: "Welcome, {{.Name}}!",
+		Body:    "Hi **{{.Name}}**, you're in {{.OrgName}}.",
+	}
+	vars := StandardVars("alogin", "Alice", "Acme")
+
+	rendered, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render: %s", err)
+	}
+	if rendered.Subject != "Welcome, Alice!" {
+		t.Errorf("Subject = %q, want %q", rendered.Subject, "Welcome, Alice!")
+	}
+	if rendered.Text != "Hi **Alice**, you're in Acme." {
+		t.Errorf("Text = %q, want the raw Markdown with vars substituted", rendered.Text)
+	}
+	if !strings.Contains(rendered.HTML, "<strong>Alice</strong>") {
+		t.Errorf("HTML = %q, want it to contain rendered <strong>Alice</strong>", rendered.HTML)
+	}
+}
+
+func TestRenderMissingVarRendersNoValue(t *testing.T) {
+	tmpl := &Template{Subject: "Hi {{.Login}}", Body: "body"}
+
+	rendered, err := Render(tmpl, map[string]string{})
+	if err != nil {
+		t.Fatalf("Render: %s", err)
+	}
+	// text/template has no notion of an optional field: a key absent from
+	// vars renders as the literal string "<no value>" rather than empty.
+	if rendered.Subject != "Hi <no value>" {
+		t.Errorf("Subject = %q, want %q", rendered.Subject, "Hi <no value>")
+	}
+}
+
+func TestRenderInvalidTemplateErrors(t *testing.T) {
+	tmpl := &Template{Subject: "{{.Broken", Body: "body"}
+	if _, err := Render(tmpl, map[string]string{}); err == nil {
+		t.Error("expected Render to error on an unparsable subject template")
+	}
+}